@@ -0,0 +1,351 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+// crossesAntimeridian returns true when a series of longitude/latitude
+// points jumps more than 180 degrees of longitude from one point to the
+// next, which means that it takes the short way across ±180.
+func crossesAntimeridian(points []geometry.Point) bool {
+	for i := 1; i < len(points); i++ {
+		if math.Abs(points[i].X-points[i-1].X) > 180 {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapPoints returns the points with longitudes that continue past ±180,
+// rather than jumping to the other side, such as 170 to 190 instead of 170
+// to -170.
+func unwrapPoints(points []geometry.Point) []geometry.Point {
+	unwrapped := make([]geometry.Point, len(points))
+	var shift float64
+	for i, point := range points {
+		if i > 0 {
+			delta := point.X - points[i-1].X
+			if delta > 180 {
+				shift -= 360
+			} else if delta < -180 {
+				shift += 360
+			}
+		}
+		unwrapped[i] = geometry.Point{X: point.X + shift, Y: point.Y}
+	}
+	return unwrapped
+}
+
+// antimeridianWindows are the longitude ranges of unwrapped points that are
+// split apart, and the shifts that move them back to -180 to 180.
+var antimeridianWindows = [3]struct{ min, max, shift float64 }{
+	{-540, -180, 360},
+	{-180, 180, 0},
+	{180, 540, -360},
+}
+
+// shiftPoints moves points by a number of degrees of longitude. Points that
+// land very near ±180 are placed exactly on it, so that the parts on each
+// side of a split meet.
+func shiftPoints(points []geometry.Point, shift float64) []geometry.Point {
+	shifted := make([]geometry.Point, len(points))
+	for i, point := range points {
+		point.X += shift
+		if math.Abs(math.Abs(point.X)-180) < 1e-9 {
+			point.X = math.Copysign(180, point.X)
+		}
+		shifted[i] = point
+	}
+	return shifted
+}
+
+func seriesPoints(series geometry.Series) []geometry.Point {
+	points := make([]geometry.Point, series.NumPoints())
+	for i := range points {
+		points[i] = series.PointAt(i)
+	}
+	return points
+}
+
+// splitValues carries the extra coordinate values, such as Z, of the points
+// of an unwrapped line or polygon over to the parts that it's split into.
+// The points where it's cut get values that are interpolated between the
+// points on either side.
+type splitValues struct {
+	dims   int
+	m      bool
+	rings  [][]geometry.Point
+	starts []int // the index of the first point of each ring
+	values []float64
+	index  map[geometry.Point]int
+}
+
+// newSplitValues returns the values of the extra for the points of the
+// rings, or nil when there are no extra coordinate values.
+func newSplitValues(rings [][]geometry.Point, ex *extra) *splitValues {
+	if ex == nil || ex.dims == 0 {
+		return nil
+	}
+	sv := &splitValues{
+		dims:   int(ex.dims),
+		m:      ex.m,
+		rings:  rings,
+		values: ex.values,
+		index:  make(map[geometry.Point]int),
+	}
+	var n int
+	for _, ring := range rings {
+		sv.starts = append(sv.starts, n)
+		for _, point := range ring {
+			if _, ok := sv.index[point]; !ok {
+				sv.index[point] = n
+			}
+			n++
+		}
+	}
+	return sv
+}
+
+// appendValues appends the extra coordinate values of a point of a part.
+func (sv *splitValues) appendValues(dst []float64, point geometry.Point,
+) []float64 {
+	if i, ok := sv.index[point]; ok {
+		return append(dst, sv.values[i*sv.dims:(i+1)*sv.dims]...)
+	}
+	// the point is on the segment that is nearest to it
+	var best int
+	var bestT float64
+	bestDist := math.Inf(+1)
+	for r, ring := range sv.rings {
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			dx, dy := b.X-a.X, b.Y-a.Y
+			var t float64
+			if lenSq := dx*dx + dy*dy; lenSq > 0 {
+				t = ((point.X-a.X)*dx + (point.Y-a.Y)*dy) / lenSq
+				t = math.Max(0, math.Min(1, t))
+			}
+			ex, ey := a.X+dx*t-point.X, a.Y+dy*t-point.Y
+			if dist := ex*ex + ey*ey; dist < bestDist {
+				best, bestT, bestDist = sv.starts[r]+i-1, t, dist
+			}
+		}
+	}
+	for i := 0; i < sv.dims; i++ {
+		a, b := sv.values[best*sv.dims+i], sv.values[(best+1)*sv.dims+i]
+		dst = append(dst, a+(b-a)*bestT)
+	}
+	return dst
+}
+
+// extra returns the extra coordinate values of the points of a part, which
+// are in the unwrapped longitudes.
+func (sv *splitValues) extra(rings ...[]geometry.Point) *extra {
+	if sv == nil {
+		return nil
+	}
+	ex := &extra{dims: byte(sv.dims), m: sv.m}
+	for _, ring := range rings {
+		for _, point := range ring {
+			ex.values = sv.appendValues(ex.values, point)
+		}
+	}
+	return ex
+}
+
+// splitAntimeridianLine returns the parts of a line on each side of the
+// antimeridian, and their extra coordinate values, or nil when the line does
+// not cross it.
+func splitAntimeridianLine(points []geometry.Point, ex *extra,
+) ([][]geometry.Point, []*extra) {
+	if !crossesAntimeridian(points) {
+		return nil, nil
+	}
+	unwrapped := geometry.NewLine(unwrapPoints(points), nil)
+	sv := newSplitValues([][]geometry.Point{seriesPoints(unwrapped)}, ex)
+	rect := unwrapped.Rect()
+	var parts [][]geometry.Point
+	var extras []*extra
+	for _, w := range antimeridianWindows {
+		window := geometry.Rect{
+			Min: geometry.Point{X: w.min, Y: rect.Min.Y},
+			Max: geometry.Point{X: w.max, Y: rect.Max.Y},
+		}
+		for _, part := range unwrapped.ClipToRect(window) {
+			parts = append(parts, shiftPoints(part, w.shift))
+			extras = append(extras, sv.extra(part))
+		}
+	}
+	return parts, extras
+}
+
+// splitAntimeridianPoly returns the parts of a polygon on each side of the
+// antimeridian, and their extra coordinate values, or nil when the polygon
+// does not cross it. A polygon that goes around a pole is not split, because
+// it has no sides.
+func splitAntimeridianPoly(rings [][]geometry.Point, ex *extra,
+	gopts *geometry.IndexOptions,
+) ([]*geometry.Poly, []*extra) {
+	var crosses bool
+	for _, ring := range rings {
+		if crossesAntimeridian(ring) {
+			crosses = true
+		}
+	}
+	if !crosses {
+		return nil, nil
+	}
+	exterior := unwrapPoints(rings[0])
+	if exterior[0] != exterior[len(exterior)-1] {
+		// around a pole
+		return nil, nil
+	}
+	center := geometry.NewLine(exterior, nil).Rect().Center()
+	var holes [][]geometry.Point
+	for _, ring := range rings[1:] {
+		hole := unwrapPoints(ring)
+		if hole[0] != hole[len(hole)-1] {
+			return nil, nil
+		}
+		// holes go in the same range of longitudes as the exterior
+		shift := 360 * math.Round(
+			(center.X-geometry.NewLine(hole, nil).Rect().Center().X)/360)
+		holes = append(holes, shiftPoints(hole, shift))
+	}
+	sv := newSplitValues(append([][]geometry.Point{exterior}, holes...), ex)
+	return splitUnwrappedPoly(geometry.NewPoly(exterior, holes, nil), sv, gopts)
+}
+
+// splitUnwrappedPoly returns the parts of a polygon with unwrapped
+// longitudes, which may go past ±180, that are in each antimeridian window,
+// shifted back to -180 to 180, and their extra coordinate values.
+func splitUnwrappedPoly(unwrapped *geometry.Poly, sv *splitValues,
+	gopts *geometry.IndexOptions,
+) ([]*geometry.Poly, []*extra) {
+	rect := unwrapped.Rect()
+	var parts []*geometry.Poly
+	var extras []*extra
+	for _, w := range antimeridianWindows {
+		window := geometry.Rect{
+			Min: geometry.Point{X: w.min, Y: rect.Min.Y},
+			Max: geometry.Point{X: w.max, Y: rect.Max.Y},
+		}
+		clipped := geometry.Overlay([]*geometry.Poly{unwrapped},
+			[]*geometry.Poly{{Exterior: window}}, geometry.OverlayIntersection)
+		for _, poly := range clipped {
+			rings := polyPoints(poly)
+			var holes [][]geometry.Point
+			for _, hole := range rings[1:] {
+				holes = append(holes, shiftPoints(hole, w.shift))
+			}
+			parts = append(parts, geometry.NewPoly(
+				shiftPoints(rings[0], w.shift), holes, gopts))
+			extras = append(extras, sv.extra(rings...))
+		}
+	}
+	return parts, extras
+}
+
+func polyPoints(poly *geometry.Poly) [][]geometry.Point {
+	rings := [][]geometry.Point{seriesPoints(poly.Exterior)}
+	for _, hole := range poly.Holes {
+		rings = append(rings, seriesPoints(hole))
+	}
+	return rings
+}
+
+// membersExtra returns the extra members of an object without its extra
+// coordinate values, which go to the parts of a split object instead.
+func membersExtra(ex *extra) *extra {
+	if ex == nil || ex.members == "" {
+		return nil
+	}
+	return &extra{members: ex.members}
+}
+
+// splitAntimeridian returns the object with the lines and polygons that
+// cross the antimeridian split into a MultiLineString or MultiPolygon, as
+// described in RFC 7946 section 3.1.9. Extra coordinate values, such as Z,
+// are interpolated at the cuts.
+func splitAntimeridian(obj Object, opts *ParseOptions) Object {
+	gopts := toGeometryOpts(opts)
+	switch g := obj.(type) {
+	case *LineString:
+		parts, extras := splitAntimeridianLine(seriesPoints(&g.base), g.extra)
+		if parts == nil {
+			break
+		}
+		split := &MultiLineString{}
+		for i, part := range parts {
+			split.children = append(split.children, &LineString{
+				base:  *geometry.NewLine(part, &gopts),
+				extra: extras[i],
+			})
+		}
+		split.extra = membersExtra(g.extra)
+		split.parseInitRectIndex(opts)
+		return split
+	case *Polygon:
+		parts, extras := splitAntimeridianPoly(polyPoints(&g.base), g.extra,
+			&gopts)
+		if parts == nil {
+			break
+		}
+		split := &MultiPolygon{}
+		for i, part := range parts {
+			split.children = append(split.children,
+				&Polygon{base: *part, extra: extras[i]})
+		}
+		split.extra = membersExtra(g.extra)
+		split.parseInitRectIndex(opts)
+		return split
+	case *MultiLineString:
+		var children []Object
+		var changed bool
+		for _, child := range g.children {
+			split := splitAntimeridian(child, opts)
+			if multi, ok := split.(*MultiLineString); ok {
+				children = append(children, multi.children...)
+				changed = true
+			} else {
+				children = append(children, split)
+			}
+		}
+		if changed {
+			g.children = children
+			g.parseInitRectIndex(opts)
+		}
+	case *MultiPolygon:
+		var children []Object
+		var changed bool
+		for _, child := range g.children {
+			split := splitAntimeridian(child, opts)
+			if multi, ok := split.(*MultiPolygon); ok {
+				children = append(children, multi.children...)
+				changed = true
+			} else {
+				children = append(children, split)
+			}
+		}
+		if changed {
+			g.children = children
+			g.parseInitRectIndex(opts)
+		}
+	case *GeometryCollection:
+		// members of a GeoJSON collection are already split when they are
+		// parsed, but WKT and WKB members are not
+		var changed bool
+		for i, child := range g.children {
+			if split := splitAntimeridian(child, opts); split != child {
+				g.children[i] = split
+				changed = true
+			}
+		}
+		if changed {
+			g.parseInitRectIndex(opts)
+		}
+	}
+	return obj
+}
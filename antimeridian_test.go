@@ -0,0 +1,79 @@
+package geojson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestSplitAntimeridian(t *testing.T) {
+	opts := *DefaultParseOptions
+	opts.SplitAntimeridian = true
+	pacific := `{"type":"Polygon","coordinates":[` +
+		`[[170,-10],[-170,-10],[-170,10],[170,10],[170,-10]]]}`
+
+	// without the option the polygon goes the long way around
+	wide := expectJSON(t, pacific, nil)
+	expect(t, wide.Contains(PO(0, 0)) && !wide.Contains(PO(175, 0)))
+
+	split := expectJSONOpts(t, pacific, `{"type":"MultiPolygon","coordinates":[`+
+		`[[[170,-10],[180,-10],[180,10],[170,10],[170,-10]]],`+
+		`[[[-180,-10],[-170,-10],[-170,10],[-180,10],[-180,-10]]]]}`, &opts)
+	expect(t, split.Contains(PO(175, 0)) && split.Contains(PO(-175, 0)))
+	expect(t, !split.Contains(PO(0, 0)) && !split.Intersects(PO(0, 0)))
+	expect(t, split.Intersects(LO([]geometry.Point{P(175, 20), P(175, -20)})))
+	expect(t, split.(*MultiPolygon).Area() == 400)
+
+	// a hole on the other side
+	splitHoled, err := Parse(`{"type":"Polygon","coordinates":[`+
+		`[[170,-10],[-170,-10],[-170,10],[170,10],[170,-10]],`+
+		`[[-178,-2],[-178,2],[-174,2],[-174,-2],[-178,-2]]]}`, &opts)
+	expect(t, err == nil)
+	expect(t, splitHoled.(*MultiPolygon).Area() == 400-16)
+	expect(t, !splitHoled.Contains(PO(-176, 0)) &&
+		splitHoled.Contains(PO(-172, 0)))
+
+	// a route across the pacific
+	route := expectJSONOpts(t,
+		`{"type":"LineString","coordinates":[[170,0],[-170,10]]}`,
+		`{"type":"MultiLineString","coordinates":[[[170,0],[180,5]],[[-180,5],[-170,10]]]}`,
+		&opts)
+	expect(t, route.Intersects(PO(180, 5)) && !route.Intersects(PO(0, 5)))
+
+	// extra coordinates are interpolated at the cut
+	expectJSONOpts(t,
+		`{"type":"LineString","coordinates":[[170,0,100],[-170,10,200]]}`,
+		`{"type":"MultiLineString","coordinates":[[[170,0,100],[180,5,150]],`+
+			`[[-180,5,150],[-170,10,200]]]}`, &opts)
+	zpoly, err := Parse(`{"type":"Polygon","coordinates":[[[170,-10,1],`+
+		`[-170,-10,2],[-170,10,3],[170,10,4],[170,-10,1]]],"id":7}`, &opts)
+	expect(t, err == nil)
+	expect(t, !zpoly.Contains(PO(0, 0)) && zpoly.Contains(PO(175, 0)))
+	json := zpoly.JSON()
+	expect(t, strings.Contains(json, "[180,-10,1.5]") &&
+		strings.Contains(json, "[-180,-10,1.5]") &&
+		strings.Contains(json, "[180,10,3.5]") &&
+		strings.Contains(json, "[-180,10,3.5]") &&
+		strings.HasSuffix(json, `,"id":7}`))
+	expectJSON(t, json, nil)
+
+	// members of collections and features are split too
+	mp, err := Parse(`{"type":"MultiPolygon","coordinates":[`+
+		`[[[170,-10],[-170,-10],[-170,10],[170,10],[170,-10]]],`+
+		`[[[0,0],[1,0],[1,1],[0,1],[0,0]]]]}`, &opts)
+	expect(t, err == nil && len(mp.(*MultiPolygon).Children()) == 3)
+	feature, err := Parse(`{"type":"Feature","geometry":`+pacific+
+		`,"properties":{"name":"pacific"}}`, &opts)
+	expect(t, err == nil && !feature.Contains(PO(0, 0)))
+	expect(t, feature.Members() == `{"properties":{"name":"pacific"}}`)
+	wkt, err := Parse(`GEOMETRYCOLLECTION(LINESTRING(170 0,-170 10))`, &opts)
+	expect(t, err == nil && !wkt.Intersects(PO(0, 5)))
+
+	// polygons that don't cross are unchanged
+	expectJSONOpts(t, `{"type":"Polygon","coordinates":[`+
+		`[[-100,-10],[70,-10],[70,10],[-100,10],[-100,-10]]]}`, nil, &opts)
+	// and a polygon around a pole is not split
+	expectJSONOpts(t, `{"type":"Polygon","coordinates":[`+
+		`[[-180,80],[-90,80],[0,80],[90,80],[180,80],[-180,80]]]}`, nil, &opts)
+}
@@ -0,0 +1,379 @@
+package geojson
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/tidwall/geojson/geometry"
+	"github.com/tidwall/gjson"
+)
+
+// binaryVersion is the first byte of every binary encoded object.
+const binaryVersion = 1
+
+// binary object types
+const (
+	binPoint byte = iota + 1
+	binLineString
+	binPolygon
+	binMultiPoint
+	binMultiLineString
+	binMultiPolygon
+	binGeometryCollection
+	binFeature
+	binFeatureCollection
+	binRect
+	binCircle
+	binSimplePoint
+)
+
+func appendBinaryUint32(dst []byte, n uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], n)
+	return append(dst, b[:]...)
+}
+
+func appendBinaryFloat(dst []byte, f float64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	return append(dst, b[:]...)
+}
+
+func appendBinaryPoint(dst []byte, point geometry.Point) []byte {
+	dst = appendBinaryFloat(dst, point.X)
+	return appendBinaryFloat(dst, point.Y)
+}
+
+func appendBinaryString(dst []byte, s string) []byte {
+	dst = appendBinaryUint32(dst, uint32(len(s)))
+	return append(dst, s...)
+}
+
+func (ex *extra) appendBinary(dst []byte) []byte {
+	if ex == nil {
+		return append(dst, 0)
+	}
+	if ex.m {
+		dst = append(dst, 2, ex.dims)
+	} else {
+		dst = append(dst, 1, ex.dims)
+	}
+	dst = appendBinaryUint32(dst, uint32(len(ex.values)))
+	for _, v := range ex.values {
+		dst = appendBinaryFloat(dst, v)
+	}
+	return appendBinaryString(dst, ex.members)
+}
+
+// appendBinaryGeometry appends a length prefixed geometry.Line or
+// geometry.Poly.
+func appendBinaryGeometry(
+	dst []byte, appendGeom func(dst []byte) []byte,
+) []byte {
+	mark := len(dst)
+	dst = append(dst, 0, 0, 0, 0)
+	dst = appendGeom(dst)
+	binary.LittleEndian.PutUint32(dst[mark:], uint32(len(dst)-mark-4))
+	return dst
+}
+
+func appendBinaryChildren(dst []byte, children []Object) []byte {
+	dst = appendBinaryUint32(dst, uint32(len(children)))
+	for _, child := range children {
+		dst = appendBinaryObject(dst, child)
+	}
+	return dst
+}
+
+func appendBinaryObject(dst []byte, obj Object) []byte {
+	switch g := obj.(type) {
+	case *Point:
+		dst = append(dst, binPoint)
+		dst = appendBinaryPoint(dst, g.base)
+		dst = g.extra.appendBinary(dst)
+	case *SimplePoint:
+		dst = append(dst, binSimplePoint)
+		dst = appendBinaryPoint(dst, g.Point)
+	case *LineString:
+		dst = append(dst, binLineString)
+		dst = appendBinaryGeometry(dst, g.base.AppendBinary)
+		dst = g.extra.appendBinary(dst)
+	case *Polygon:
+		dst = append(dst, binPolygon)
+		dst = appendBinaryGeometry(dst, g.base.AppendBinary)
+		dst = g.extra.appendBinary(dst)
+	case *Rect:
+		dst = append(dst, binRect)
+		dst = appendBinaryPoint(dst, g.base.Min)
+		dst = appendBinaryPoint(dst, g.base.Max)
+	case *Circle:
+		dst = append(dst, binCircle)
+		dst = appendBinaryPoint(dst, g.center)
+		dst = appendBinaryFloat(dst, g.meters)
+		dst = appendBinaryUint32(dst, uint32(g.steps))
+		dst = appendBinaryString(dst, string(g.Units()))
+		dst = appendBinaryFloat(dst, g.Radius(g.Units()))
+	case *Feature:
+		dst = append(dst, binFeature)
+		dst = appendBinaryObject(dst, g.base)
+		dst = g.extra.appendBinary(dst)
+	case *MultiPoint:
+		dst = append(dst, binMultiPoint)
+		dst = appendBinaryChildren(dst, g.children)
+		dst = g.extra.appendBinary(dst)
+	case *MultiLineString:
+		dst = append(dst, binMultiLineString)
+		dst = appendBinaryChildren(dst, g.children)
+		dst = g.extra.appendBinary(dst)
+	case *MultiPolygon:
+		dst = append(dst, binMultiPolygon)
+		dst = appendBinaryChildren(dst, g.children)
+		dst = g.extra.appendBinary(dst)
+	case *GeometryCollection:
+		dst = append(dst, binGeometryCollection)
+		dst = appendBinaryChildren(dst, g.Children())
+		dst = g.extra.appendBinary(dst)
+	case *FeatureCollection:
+		dst = append(dst, binFeatureCollection)
+		dst = appendBinaryChildren(dst, g.Children())
+		dst = g.extra.appendBinary(dst)
+	case *collection:
+		dst = append(dst, binGeometryCollection)
+		dst = appendBinaryChildren(dst, g.Children())
+		dst = g.extra.appendBinary(dst)
+	}
+	return dst
+}
+
+// appendBinary appends the native binary representation of the object,
+// including the prebuilt geometry segment indexes.
+func appendBinary(dst []byte, obj Object) []byte {
+	dst = append(dst, binaryVersion)
+	return appendBinaryObject(dst, obj)
+}
+
+// binReader is a cursor over binary data.
+type binReader struct {
+	data []byte
+	i    int
+	err  error
+}
+
+func (r *binReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data)-r.i {
+		r.err = errBinaryInvalid
+		return nil
+	}
+	b := r.data[r.i : r.i+n]
+	r.i += n
+	return b
+}
+
+func (r *binReader) byte() byte {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *binReader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *binReader) float() float64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+func (r *binReader) point() geometry.Point {
+	return geometry.Point{X: r.float(), Y: r.float()}
+}
+
+// count reads an element count and checks that there's enough data remaining
+// for each element to be at least minSize bytes.
+func (r *binReader) count(minSize int) int {
+	n := int(r.uint32())
+	if r.err == nil && n*minSize > len(r.data)-r.i {
+		r.err = errBinaryInvalid
+		return 0
+	}
+	return n
+}
+
+// extra reads the extra coordinates and members of an object that has
+// numPoints points, and checks that they can be written back as GeoJSON.
+func (r *binReader) extra(numPoints int) *extra {
+	kind := r.byte()
+	if kind == 0 {
+		return nil
+	}
+	ex := new(extra)
+	ex.m = kind == 2 // the values are M rather than Z
+	ex.dims = r.byte()
+	n := r.count(8)
+	if r.err == nil && (kind > 2 || ex.dims > 2 || ex.m && ex.dims != 1 ||
+		n != int(ex.dims)*numPoints) {
+		r.err = errBinaryInvalid
+	}
+	if r.err != nil {
+		return nil
+	}
+	if n > 0 {
+		ex.values = make([]float64, n)
+		for i := 0; i < n; i++ {
+			ex.values[i] = r.float()
+		}
+	}
+	ex.members = string(r.bytes(int(r.uint32())))
+	if ex.members != "" && (len(ex.members) < 2 ||
+		ex.members[0] != '{' || ex.members[len(ex.members)-1] != '}' ||
+		!gjson.Valid(ex.members)) {
+		r.err = errBinaryInvalid
+	}
+	if r.err != nil {
+		return nil
+	}
+	return ex
+}
+
+func (r *binReader) children(opts *ParseOptions) []Object {
+	n := r.count(1)
+	if r.err != nil || n == 0 {
+		return nil
+	}
+	children := make([]Object, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		children[i] = r.object(opts)
+	}
+	return children
+}
+
+func (r *binReader) object(opts *ParseOptions) Object {
+	typ := r.byte()
+	if r.err != nil {
+		return nil
+	}
+	switch typ {
+	case binPoint:
+		g := new(Point)
+		g.base = r.point()
+		g.extra = r.extra(1)
+		return g
+	case binSimplePoint:
+		return &SimplePoint{Point: r.point()}
+	case binLineString:
+		g := new(LineString)
+		if err := g.base.UnmarshalBinary(r.bytes(int(r.uint32()))); err != nil {
+			r.err = errBinaryInvalid
+			return nil
+		}
+		g.extra = r.extra(g.NumPoints())
+		return g
+	case binPolygon:
+		g := new(Polygon)
+		if err := g.base.UnmarshalBinary(r.bytes(int(r.uint32()))); err != nil {
+			r.err = errBinaryInvalid
+			return nil
+		}
+		g.extra = r.extra(g.NumPoints())
+		return g
+	case binRect:
+		g := new(Rect)
+		g.base.Min = r.point()
+		g.base.Max = r.point()
+		return g
+	case binCircle:
+		center := r.point()
+		r.float() // meters, which are made again from the radius
+		steps := int(r.uint32())
+		units := Unit(r.bytes(int(r.uint32())))
+		radius := r.float()
+		if r.err != nil {
+			return nil
+		}
+		g, err := NewCircleWithUnits(center, radius, units,
+			&CircleOptions{Steps: steps})
+		if err != nil {
+			r.err = errBinaryInvalid
+			return nil
+		}
+		return g
+	case binFeature:
+		g := new(Feature)
+		g.base = r.object(opts)
+		g.extra = r.extra(0)
+		return g
+	case binMultiPoint:
+		g := new(MultiPoint)
+		r.collection(&g.collection, opts)
+		return g
+	case binMultiLineString:
+		g := new(MultiLineString)
+		r.collection(&g.collection, opts)
+		return g
+	case binMultiPolygon:
+		g := new(MultiPolygon)
+		r.collection(&g.collection, opts)
+		return g
+	case binGeometryCollection:
+		g := new(GeometryCollection)
+		r.collection(&g.collection, opts)
+		return g
+	case binFeatureCollection:
+		g := new(FeatureCollection)
+		r.collection(&g.collection, opts)
+		return g
+	}
+	r.err = errBinaryInvalid
+	return nil
+}
+
+func (r *binReader) collection(g *collection, opts *ParseOptions) {
+	g.children = r.children(opts)
+	g.extra = r.extra(0)
+	if r.err == nil {
+		g.parseInitRectIndex(opts)
+	}
+}
+
+// ParseBinary loads an object from data that was created by the object's
+// MarshalBinary method. Line and polygon segment indexes are restored as-is,
+// rather than rebuilt, making this much faster than Parse for large polygons.
+// Only the IndexChildren option is used.
+func ParseBinary(data []byte, opts *ParseOptions) (Object, error) {
+	if opts == nil {
+		opts = DefaultParseOptions
+	}
+	r := &binReader{data: data}
+	if r.byte() != binaryVersion {
+		return nil, errBinaryInvalid
+	}
+	o := r.object(opts)
+	if r.err == nil && r.i != len(r.data) {
+		r.err = errBinaryInvalid
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return o, nil
+}
+
+// unmarshalBinary loads an object from data and checks that it's the
+// expected type.
+func unmarshalBinary(data []byte, typ byte) (Object, error) {
+	if len(data) < 2 || data[1] != typ {
+		return nil, errBinaryInvalid
+	}
+	return ParseBinary(data, nil)
+}
@@ -0,0 +1,184 @@
+package geojson
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func expectBinary(t *testing.T, obj Object) Object {
+	t.Helper()
+	data, err := obj.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj2, err := ParseBinary(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.JSON() != obj2.JSON() {
+		t.Fatalf("expected '%v', got '%v'", obj.JSON(), obj2.JSON())
+	}
+	data2, _ := obj2.MarshalBinary()
+	if !bytes.Equal(data, data2) {
+		t.Fatal("binary mismatch")
+	}
+	// truncated data must fail
+	for i := 0; i < len(data); i += 1 + len(data)/50 {
+		if _, err := ParseBinary(data[:i], nil); err == nil {
+			t.Fatalf("expected error for %d bytes", i)
+		}
+	}
+	return obj2
+}
+
+func TestBinary(t *testing.T) {
+	opts := &ParseOptions{IndexChildren: 1, IndexGeometry: 1,
+		IndexGeometryKind: geometry.QuadTree}
+	for _, json := range []string{
+		`{"type":"Point","coordinates":[1,2]}`,
+		`{"type":"Point","coordinates":[1,2,3,4],"id":"a"}`,
+		`{"type":"LineString","coordinates":[[1,2,3],[3,4,5]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`,
+		`{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`,
+		`{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[10,0],[10,10],[0,0]]]],"bbox":[0,0,10,10]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]}`,
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{"a":"b"}}`,
+		`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}}]}`,
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{"type":"Circle","radius":1000,"radius_units":"m"}}`,
+	} {
+		g, err := Parse(json, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectBinary(t, g)
+	}
+	expectBinary(t, RO(1, 2, 3, 4))
+	expectBinary(t, NewSimplePoint(P(1, 2)))
+
+	// collections are reindexed on load
+	g := expectBinary(t, expectJSON(t,
+		`{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`, nil))
+	c, _ := ParseBinary(mustMarshalBinary(g), opts)
+	expect(t, c.(Collection).Indexed())
+	expect(t, c.Intersects(PO(3, 4)))
+
+	// a bare collection is written as a GeometryCollection
+	data, err := g.(*MultiPoint).collection.MarshalBinary()
+	expect(t, err == nil)
+	c, err = ParseBinary(data, nil)
+	expect(t, err == nil)
+	expect(t, c.JSON() == `{"type":"GeometryCollection","geometries":[`+
+		`{"type":"Point","coordinates":[1,2]},`+
+		`{"type":"Point","coordinates":[3,4]}]}`)
+
+	// typed unmarshaling
+	var p Polygon
+	expect(t, p.UnmarshalBinary(mustMarshalBinary(PO(1, 2))) == errBinaryInvalid)
+	expect(t, p.UnmarshalBinary(mustMarshalBinary(PPO(
+		[]geometry.Point{P(0, 0), P(10, 0), P(10, 10), P(0, 0)}, nil))) == nil)
+	expect(t, p.Contains(PO(8, 2)))
+
+	_, err = ParseBinary([]byte{0, binPoint}, nil)
+	expect(t, err == errBinaryInvalid)
+	_, err = ParseBinary([]byte{binaryVersion, 99}, nil)
+	expect(t, err == errBinaryInvalid)
+}
+
+func TestBinaryCorruptExtra(t *testing.T) {
+	point := func(kind, dims byte, values int, members string) []byte {
+		data := []byte{binaryVersion, binPoint}
+		data = appendBinaryPoint(data, P(1, 2))
+		data = append(data, kind, dims)
+		data = appendBinaryUint32(data, uint32(values))
+		for i := 0; i < values; i++ {
+			data = appendBinaryFloat(data, float64(i))
+		}
+		return appendBinaryString(data, members)
+	}
+	g, err := ParseBinary(point(1, 1, 1, `{"id":1}`), nil)
+	expect(t, err == nil)
+	expect(t, g.JSON() == `{"type":"Point","coordinates":[1,2,0],"id":1}`)
+	g, err = ParseBinary(point(2, 1, 1, ""), nil)
+	expect(t, err == nil)
+	expect(t, g.(*Point).extra.m)
+	for _, data := range [][]byte{
+		point(1, 3, 3, ""),
+		point(1, 1, 2, ""),
+		point(1, 2, 1, ""),
+		point(1, 0, 1, ""),
+		point(1, 1, 1, "x"),
+		point(1, 1, 1, "{"),
+		point(1, 1, 1, `"id":1}`),
+		point(1, 1, 1, `{"id":}`),
+		point(2, 2, 2, ""),
+		point(3, 1, 1, ""),
+	} {
+		_, err := ParseBinary(data, nil)
+		expect(t, err == errBinaryInvalid)
+	}
+
+	// the values must match the number of points in a line
+	line := mustMarshalBinary(expectJSON(t,
+		`{"type":"LineString","coordinates":[[1,2,3],[3,4,5]]}`, nil))
+	values := func(values ...float64) []byte {
+		// replace the count, the values, and the empty members
+		data := append([]byte{}, line[:len(line)-4-2*8-4]...)
+		data = appendBinaryUint32(data, uint32(len(values)))
+		for _, v := range values {
+			data = appendBinaryFloat(data, v)
+		}
+		return appendBinaryString(data, "")
+	}
+	_, err = ParseBinary(values(3, 5), nil)
+	expect(t, err == nil)
+	_, err = ParseBinary(values(3), nil)
+	expect(t, err == errBinaryInvalid)
+
+	// collections have no extra coordinates of their own
+	mp := mustMarshalBinary(expectJSON(t,
+		`{"type":"MultiPoint","coordinates":[[1,2]]}`, nil))
+	mp = append(mp[:len(mp)-1], 1, 1)
+	mp = appendBinaryUint32(mp, 1)
+	mp = appendBinaryFloat(mp, 3)
+	mp = appendBinaryString(mp, "")
+	_, err = ParseBinary(mp, nil)
+	expect(t, err == errBinaryInvalid)
+}
+
+func TestBinaryPersistsIndex(t *testing.T) {
+	data, err := ioutil.ReadFile("test_files/boston_subset.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &ParseOptions{IndexChildren: 64, IndexGeometry: 16,
+		IndexGeometryKind: geometry.RTree}
+	g, err := Parse(string(data), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2 := expectBinary(t, g)
+	var n int
+	g2.ForEach(func(geom Object) bool {
+		if f, ok := geom.(*Feature); ok {
+			geom = f.Base()
+		}
+		if p, ok := geom.(*Polygon); ok && p.NumPoints() >= 16 {
+			expect(t, p.Base().Exterior.Index() != nil)
+			n++
+		}
+		return true
+	})
+	expect(t, n > 0)
+}
+
+func mustMarshalBinary(obj Object) []byte {
+	data, err := obj.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,101 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+)
+
+// bufferProjection is an equirectangular projection of longitude and
+// latitude to meters on a plane that touches the earth at a center point.
+// It's accurate near the center, which is enough for buffers that are small
+// compared to the earth, such as corridors around routes and setback zones
+// around parcels.
+type bufferProjection struct {
+	center geometry.Point
+	scaleX float64 // meters per degree of longitude
+	scaleY float64 // meters per degree of latitude
+}
+
+func newBufferProjection(center geometry.Point) bufferProjection {
+	scaleY := geo.DistanceTo(0, 0, 1, 0)
+	return bufferProjection{
+		center: center,
+		scaleX: scaleY * math.Cos(center.Y*math.Pi/180),
+		scaleY: scaleY,
+	}
+}
+
+func (proj bufferProjection) projectPoint(point geometry.Point,
+) geometry.Point {
+	return geometry.Point{
+		X: (point.X - proj.center.X) * proj.scaleX,
+		Y: (point.Y - proj.center.Y) * proj.scaleY,
+	}
+}
+
+func (proj bufferProjection) project(series geometry.Series,
+) []geometry.Point {
+	points := make([]geometry.Point, series.NumPoints())
+	for i := range points {
+		points[i] = proj.projectPoint(series.PointAt(i))
+	}
+	return points
+}
+
+func (proj bufferProjection) unproject(series geometry.Series,
+) []geometry.Point {
+	points := make([]geometry.Point, series.NumPoints())
+	for i := range points {
+		point := series.PointAt(i)
+		points[i] = geometry.Point{
+			X: point.X/proj.scaleX + proj.center.X,
+			Y: point.Y/proj.scaleY + proj.center.Y,
+		}
+	}
+	return points
+}
+
+// buffer returns the area within a distance in meters of an object. The
+// result is a Polygon when there is exactly one polygon, otherwise it's a
+// MultiPolygon, which is empty when there is no area.
+func buffer(obj Object, meters float64, opts *geometry.BufferOptions) Object {
+	proj := newBufferProjection(obj.Center())
+	var polys []*geometry.Poly
+	for _, part := range appendObjectParts(nil, obj, 0, true) {
+		switch {
+		case part.poly != nil:
+			exterior := proj.project(part.poly.Exterior)
+			var holes [][]geometry.Point
+			for _, hole := range part.poly.Holes {
+				holes = append(holes, proj.project(hole))
+			}
+			poly := geometry.NewPoly(exterior, holes,
+				geometry.DefaultIndexOptions)
+			polys = append(polys, poly.Buffer(meters, opts)...)
+		case part.line != nil:
+			line := geometry.NewLine(proj.project(part.line),
+				geometry.DefaultIndexOptions)
+			polys = append(polys, line.Buffer(meters, opts)...)
+		default:
+			point := proj.projectPoint(part.point)
+			polys = append(polys, point.Buffer(meters, opts)...)
+		}
+	}
+	if len(polys) > 1 {
+		polys = geometry.UnionPolys(polys)
+	}
+	for i, poly := range polys {
+		exterior := proj.unproject(poly.Exterior)
+		var holes [][]geometry.Point
+		for _, hole := range poly.Holes {
+			holes = append(holes, proj.unproject(hole))
+		}
+		polys[i] = geometry.NewPoly(exterior, holes, geometry.DefaultIndexOptions)
+	}
+	if len(polys) == 1 {
+		return NewPolygon(polys[0])
+	}
+	return NewMultiPolygon(polys)
+}
@@ -0,0 +1,61 @@
+package geojson
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func expectGeodesicArea(t *testing.T, obj Object, area float64) {
+	t.Helper()
+	actual := obj.(areaObject).GeodesicArea()
+	if math.Abs(actual-area) > area*0.01 {
+		t.Fatalf("expected area %v, got %v", area, actual)
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	circle := PO(-112, 33).Buffer(1000, nil).(*Polygon)
+	expectGeodesicArea(t, circle, math.Pi*1000*1000)
+	expect(t, circle.Contains(PO(-112.009, 33)) &&
+		!circle.Contains(PO(-112.011, 33)))
+	expect(t, PO(-112, 33).Buffer(-1000, nil).Empty())
+
+	// a corridor around a route along the equator
+	route := LO([]geometry.Point{P(0, 0), P(0.1, 0)})
+	flat := &geometry.BufferOptions{CapStyle: geometry.CapFlat}
+	corridor := route.Buffer(100, flat).(*Polygon)
+	length := route.GeodesicLength()
+	expectGeodesicArea(t, corridor, length*200)
+	expect(t, corridor.Contains(PO(0.05, 0.0008)) &&
+		!corridor.Contains(PO(0.05, 0.001)) && !corridor.Contains(PO(-0.0001, 0)))
+	square := &geometry.BufferOptions{CapStyle: geometry.CapSquare}
+	expectGeodesicArea(t, route.Buffer(100, square), (length+200)*200)
+	expect(t, route.Buffer(-100, nil).JSON() ==
+		`{"type":"MultiPolygon","coordinates":[]}`)
+
+	// a setback zone inside of a parcel
+	parcel := RO(-112, 33, -111.99, 33.01)
+	side := math.Sqrt(parcel.GeodesicArea())
+	mitre := &geometry.BufferOptions{JoinStyle: geometry.JoinMitre}
+	expectGeodesicArea(t, parcel.Buffer(-100, mitre), (side-200)*(side-200))
+	expectGeodesicArea(t, parcel.Buffer(100, mitre), (side+200)*(side+200))
+	expect(t, parcel.Buffer(-1000, nil).Empty())
+
+	// members that are far apart make a multipolygon
+	mp := MPO([]geometry.Point{P(-112, 33), P(-111, 33)})
+	multi := mp.Buffer(1000, nil).(*MultiPolygon)
+	expect(t, len(multi.Children()) == 2)
+	expectGeodesicArea(t, multi, 2*math.Pi*1000*1000)
+	// and members that are close together are merged
+	mp = MPO([]geometry.Point{P(-112, 33), P(-111.99, 33)})
+	expect(t, len(mp.Buffer(1000, nil).(*Polygon).base.Holes) == 0)
+
+	feature := expectJSON(t,
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{}}`,
+		nil)
+	expectGeodesicArea(t, feature.Buffer(1000, nil), math.Pi*1000*1000)
+	expectGeodesicArea(t, NewCircle(P(-112, 33), 1000, 64).Buffer(1000, nil),
+		math.Pi*2000*2000)
+}
@@ -0,0 +1,134 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+// partFirstPoint returns the first point of a part.
+func partFirstPoint(part objectPart) geometry.Point {
+	switch {
+	case part.poly != nil:
+		return part.poly.Exterior.PointAt(0)
+	case part.line != nil:
+		return part.line.PointAt(0)
+	}
+	return part.point
+}
+
+// centroid returns the centroid of the highest dimension parts of an object.
+// Polygons are weighted by area, lines by length, and points are averaged.
+// The center of an empty object is used as its centroid.
+func centroid(obj Object) geometry.Point {
+	parts := appendObjectParts(nil, obj, 0, true)
+	var area, length, count float64
+	var ac, lc, pc geometry.Point
+	for _, part := range parts {
+		switch {
+		case part.poly != nil:
+			c := part.poly.Centroid()
+			if a := part.poly.Area(); a > 0 {
+				ac.X, ac.Y, area = ac.X+c.X*a, ac.Y+c.Y*a, area+a
+			} else {
+				// a polygon without area is weighted by its edges
+				l := part.poly.Length()
+				lc.X, lc.Y, length = lc.X+c.X*l, lc.Y+c.Y*l, length+l
+			}
+		case part.line != nil:
+			c, l := part.line.Centroid(), part.line.Length()
+			lc.X, lc.Y, length = lc.X+c.X*l, lc.Y+c.Y*l, length+l
+		default:
+			pc.X, pc.Y, count = pc.X+part.point.X, pc.Y+part.point.Y, count+1
+		}
+	}
+	switch {
+	case area > 0:
+		return geometry.Point{X: ac.X / area, Y: ac.Y / area}
+	case length > 0:
+		return geometry.Point{X: lc.X / length, Y: lc.Y / length}
+	case count > 0:
+		return geometry.Point{X: pc.X / count, Y: pc.Y / count}
+	case len(parts) > 0:
+		return partFirstPoint(parts[0])
+	}
+	return obj.Center()
+}
+
+func distSq(a, b geometry.Point) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// pointOnSurface returns a point that is on the highest dimension parts of
+// an object. For polygons this is inside of the polygon with the largest
+// area. For lines it's the vertex nearest to the centroid, preferring
+// vertices that are not endpoints. For points it's the point nearest to the
+// centroid.
+func pointOnSurface(obj Object) geometry.Point {
+	parts := appendObjectParts(nil, obj, 0, true)
+	if len(parts) == 0 {
+		return obj.Center()
+	}
+	var largest *geometry.Poly
+	var area float64
+	for _, part := range parts {
+		if part.poly != nil {
+			if a := part.poly.Area(); largest == nil || a > area {
+				largest, area = part.poly, a
+			}
+		}
+	}
+	if largest != nil {
+		return largest.PointOnSurface()
+	}
+	c := centroid(obj)
+	var point geometry.Point
+	best, bestEnd := math.Inf(+1), math.Inf(+1)
+	var found bool
+	for _, part := range parts {
+		if part.line == nil {
+			continue
+		}
+		n := part.line.NumPoints()
+		for i := 0; i < n; i++ {
+			p := part.line.PointAt(i)
+			d := distSq(p, c)
+			if i > 0 && i < n-1 {
+				if d < best {
+					best, point, found = d, p, true
+				}
+			} else if !found && d < bestEnd {
+				bestEnd, point = d, p
+			}
+		}
+	}
+	if !math.IsInf(best, +1) || !math.IsInf(bestEnd, +1) {
+		return point
+	}
+	for _, part := range parts {
+		if d := distSq(part.point, c); d < best {
+			best, point = d, part.point
+		}
+	}
+	return point
+}
+
+// poleOfInaccessibility returns the point inside of the polygons of an object
+// that is farthest from their edges. Objects without polygons use
+// pointOnSurface.
+func poleOfInaccessibility(obj Object, precision float64) geometry.Point {
+	var pole geometry.Point
+	best := math.Inf(-1)
+	for _, part := range appendObjectParts(nil, obj, 0, true) {
+		if part.poly != nil {
+			if p, d := part.poly.PoleOfInaccessibility(precision); d > best {
+				pole, best = p, d
+			}
+		}
+	}
+	if math.IsInf(best, -1) {
+		return pointOnSurface(obj)
+	}
+	return pole
+}
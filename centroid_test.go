@@ -0,0 +1,64 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestCentroid(t *testing.T) {
+	cshape := `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,2],[2,2],[2,8],[10,8],[10,10],[0,10],[0,0]]]}`
+	g := expectJSON(t, cshape, nil)
+	// the rect center and the centroid are outside of a C-shape
+	expect(t, !g.Contains(PO(g.Center().X, g.Center().Y)))
+	c := g.Centroid()
+	expect(t, c.Y == 5 && c.X < 5)
+	expect(t, !g.Contains(PO(c.X, c.Y)))
+	p := g.PointOnSurface()
+	expect(t, g.Contains(PO(p.X, p.Y)))
+	p = g.PoleOfInaccessibility(0)
+	expect(t, g.Contains(PO(p.X, p.Y)))
+
+	// lines are weighted by length
+	line := LO([]geometry.Point{P(0, 0), P(0, 2), P(8, 2)})
+	expect(t, line.Centroid() == P(3.2, 1.8))
+	expect(t, line.PointOnSurface() == P(0, 2))
+	expect(t, line.PoleOfInaccessibility(0) == P(0, 2))
+	expect(t, LO([]geometry.Point{P(0, 0), P(10, 0)}).PointOnSurface() == P(0, 0))
+
+	// points are averaged
+	mp := expectJSON(t, `{"type":"MultiPoint","coordinates":[[0,0],[4,0],[5,3]]}`, nil)
+	expect(t, mp.Centroid() == P(3, 1))
+	expect(t, mp.PointOnSurface() == P(4, 0))
+	expect(t, PO(1, 2).Centroid() == P(1, 2) && PO(1, 2).PointOnSurface() == P(1, 2))
+
+	// collections use their highest dimension
+	gc := expectJSON(t, `{"type":"GeometryCollection","geometries":[
+		{"type":"Point","coordinates":[100,100]},
+		{"type":"LineString","coordinates":[[50,50],[60,60]]},
+		{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]},
+		{"type":"Polygon","coordinates":[[[4,0],[6,0],[6,2],[4,2],[4,0]]]}
+	]}`, nil)
+	expect(t, gc.Centroid() == P(3, 1))
+	p = gc.PointOnSurface()
+	expect(t, p.X > 0 && p.X < 2 && p.Y > 0 && p.Y < 2)
+	mls := expectJSON(t, `{"type":"MultiLineString","coordinates":[[[0,0],[0,4]],[[10,0],[10,2]]]}`, nil)
+	expect(t, mls.Centroid() == P(10.0/3, 5.0/3))
+
+	// features, rects and circles
+	f := expectJSON(t, `{"type":"Feature","geometry":`+cshape+`,"properties":{}}`, nil)
+	expect(t, f.Centroid() == c)
+	expect(t, RO(0, 0, 10, 4).Centroid() == P(5, 2))
+	p = RO(0, 0, 10, 4).PoleOfInaccessibility(0.001)
+	expect(t, p.Y > 1.99 && p.Y < 2.01)
+	circle := NewCircle(P(-112, 33), 1000, 64)
+	expect(t, circle.Centroid() == P(-112, 33))
+	expect(t, circle.PointOnSurface() == P(-112, 33))
+	expect(t, circle.PoleOfInaccessibility(0) == P(-112, 33))
+
+	// empty objects use their center
+	empty := expectJSON(t, `{"type":"MultiPoint","coordinates":[]}`, nil)
+	expect(t, empty.Centroid() == empty.Center())
+	expect(t, empty.PointOnSurface() == empty.Center())
+	expect(t, empty.PoleOfInaccessibility(0) == empty.Center())
+}
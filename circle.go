@@ -2,32 +2,125 @@ package geojson
 
 import (
 	"math"
+	"sync"
 
 	"github.com/tidwall/geojson/geo"
 	"github.com/tidwall/geojson/geometry"
 )
 
 type Circle struct {
-	object    Object
+	cache     *circleCache
 	center    geometry.Point
 	meters    float64
+	radius    float64 // in units
+	units     Unit
 	haversine float64
 	steps     int
 }
 
+// Unit is a unit of distance for the radius of a circle, which is written
+// as the "radius_units" of the circle's GeoJSON.
+type Unit string
+
+const (
+	Meters        Unit = "m"
+	Kilometers    Unit = "km"
+	Miles         Unit = "mi"
+	Feet          Unit = "ft"
+	Yards         Unit = "yd"
+	NauticalMiles Unit = "nm"
+)
+
+// unitMeters are the number of meters in each unit.
+var unitMeters = map[Unit]float64{
+	Meters:        1,
+	Kilometers:    1000,
+	Miles:         1609.344,
+	Feet:          0.3048,
+	Yards:         0.9144,
+	NauticalMiles: 1852,
+}
+
+// circleCache holds the polygon that approximates a circle, which is made
+// once and is then shared by copies of the circle.
+type circleCache struct {
+	once   sync.Once
+	object Object
+}
+
+// CircleOptions are the options for making a Circle.
+type CircleOptions struct {
+	// Steps is the number of points in the polygon that approximates the
+	// circle, which is used for the Rect, Spatial, Distance, and the
+	// predicates that have no exact geodesic test. More steps are more
+	// precise. The minimum is 3, and zero or less uses the default.
+	// The default is 64.
+	Steps int
+	// Eager option causes the polygon to be made when the circle is made,
+	// rather than the first time that it's needed. This keeps the work out of
+	// loops that test many objects against a circle.
+	Eager bool
+}
+
+// DefaultCircleOptions are the CircleOptions that are used when none are
+// given.
+var DefaultCircleOptions = &CircleOptions{
+	Steps: 64,
+	Eager: false,
+}
+
 // NewCircle returns an circle object
 func NewCircle(center geometry.Point, meters float64, steps int) *Circle {
 	if steps < 3 {
 		steps = 3
 	}
+	return NewCircleWithOptions(center, meters, &CircleOptions{Steps: steps})
+}
+
+// NewCircleWithUnits returns a circle object with a radius in a unit, which
+// is kept when the circle is written as GeoJSON. A nil opts uses the
+// DefaultCircleOptions. An error is returned when the unit is unknown.
+func NewCircleWithUnits(center geometry.Point, radius float64, units Unit,
+	opts *CircleOptions,
+) (*Circle, error) {
+	scale, ok := unitMeters[units]
+	if !ok {
+		return nil, errCircleRadiusUnitsInvalid
+	}
+	g := NewCircleWithOptions(center, radius*scale, opts)
+	g.radius = radius
+	g.units = units
+	return g, nil
+}
+
+// NewCircleWithOptions returns a circle object. A nil opts uses the
+// DefaultCircleOptions.
+func NewCircleWithOptions(center geometry.Point, meters float64,
+	opts *CircleOptions,
+) *Circle {
+	if opts == nil {
+		opts = DefaultCircleOptions
+	}
+	steps := opts.Steps
+	if steps <= 0 {
+		steps = DefaultCircleOptions.Steps
+	} else if steps < 3 {
+		steps = 3
+	}
 	g := new(Circle)
+	g.cache = new(circleCache)
 	g.center = center
 	g.meters = meters
+	g.radius = meters
+	g.units = Meters
 	g.steps = steps
 	if meters > 0 {
 		meters = geo.NormalizeDistance(meters)
 		g.haversine = geo.DistanceToHaversine(meters)
 	}
+	if opts.Eager {
+		g.getObject()
+	}
 	return g
 }
 
@@ -38,11 +131,33 @@ func (g *Circle) AppendJSON(dst []byte) []byte {
 	dst = append(dst, ',')
 	dst = appendJSONFloat(dst, g.center.Y)
 	dst = append(dst, `]},"properties":{"type":"Circle","radius":`...)
-	dst = appendJSONFloat(dst, g.meters)
-	dst = append(dst, `,"radius_units":"m"}}`...)
+	dst = appendJSONFloat(dst, g.Radius(g.Units()))
+	dst = append(dst, `,"radius_units":"`...)
+	dst = append(dst, g.Units()...)
+	dst = append(dst, `"}}`...)
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text of the circle's polygon to dst.
+func (g *Circle) AppendWKT(dst []byte) []byte {
+	return g.getObject().AppendWKT(dst)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *Circle) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *Circle) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *Circle) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *Circle) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -51,6 +166,72 @@ func (g *Circle) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *Circle) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *Circle) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binCircle)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*Circle)
+	return nil
+}
+
+// Area returns the planar area in square coordinate units of the polygon
+// that approximates the circle.
+func (g *Circle) Area() float64 {
+	if poly, ok := g.getObject().(areaObject); ok {
+		return poly.Area()
+	}
+	return 0
+}
+
+// GeodesicArea returns the area in square meters on the WGS84 ellipsoid of
+// the circle itself, rather than of the polygon that approximates it.
+func (g *Circle) GeodesicArea() float64 {
+	// A polygon with n steps falls short of the circle by about k/n², so
+	// the areas of two finer polygons are extrapolated to the circle's.
+	a1 := circleGeodesicArea(g.center, g.meters, 256)
+	a2 := circleGeodesicArea(g.center, g.meters, 512)
+	return (4*a2 - a1) / 3
+}
+
+func circleGeodesicArea(center geometry.Point, meters float64,
+	steps int,
+) float64 {
+	obj := makeCircleObject(center, meters, steps)
+	if poly, ok := obj.(areaObject); ok {
+		return poly.GeodesicArea()
+	}
+	return 0
+}
+
+// Union returns the area that is in either the object or another object.
+func (g *Circle) Union(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayUnion)
+}
+
+// Intersection returns the area that is in both the object and another
+// object.
+func (g *Circle) Intersection(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayIntersection)
+}
+
+// Difference returns the area of the object that is not in another object.
+func (g *Circle) Difference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayDifference)
+}
+
+// SymmetricDifference returns the area that is in only one of the object and
+// another object.
+func (g *Circle) SymmetricDifference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlaySymmetricDifference)
+}
+
 func (g *Circle) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -60,6 +241,28 @@ func (g *Circle) Meters() float64 {
 	return g.meters
 }
 
+// Radius returns the circle's radius in a unit, or zero when the unit is
+// unknown.
+func (g *Circle) Radius(units Unit) float64 {
+	if units == g.Units() {
+		return g.radius
+	}
+	scale, ok := unitMeters[units]
+	if !ok {
+		return 0
+	}
+	return g.meters / scale
+}
+
+// Units returns the unit of the circle's radius, which is the unit that it
+// was made with.
+func (g *Circle) Units() Unit {
+	if g.units == "" {
+		return Meters
+	}
+	return g.units
+}
+
 // Center returns the circle's center point
 func (g *Circle) Center() geometry.Point {
 	return g.center
@@ -86,6 +289,129 @@ func (g *Circle) containsPoint(p geometry.Point) bool {
 	return h <= g.haversine
 }
 
+// containsSeries returns true if circle contains every point of a series,
+// including the farthest location on each of its segments from the center.
+func (g *Circle) containsSeries(series geometry.Series) bool {
+	if series.NumSegments() == 0 {
+		return series.NumPoints() > 0 && g.containsPoint(series.PointAt(0))
+	}
+	for i := 0; i < series.NumSegments(); i++ {
+		if !g.segmentWithin(series.SegmentAt(i), true) {
+			return false
+		}
+	}
+	return true
+}
+
+// nearSeries returns true if any segment of a series is within the radius of
+// the circle's center.
+func (g *Circle) nearSeries(series geometry.Series) bool {
+	if series.NumSegments() == 0 {
+		return series.NumPoints() > 0 && g.containsPoint(series.PointAt(0))
+	}
+	for i := 0; i < series.NumSegments(); i++ {
+		if g.segmentWithin(series.SegmentAt(i), false) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentWithin returns true if the nearest location on a segment to the
+// circle's center is within the radius, or the farthest location when far is
+// set. The segment is a straight line in longitude and latitude, like
+// everywhere else, so it's sampled for that location, which is then found
+// between the samples on either side of it.
+func (g *Circle) segmentWithin(seg geometry.Segment, far bool) bool {
+	sign := 1.0
+	if far {
+		sign = -1
+	}
+	// the haversine to the center, negated when looking for the farthest
+	at := func(t float64) float64 {
+		return sign * geo.Haversine(g.center.Y, g.center.X,
+			seg.A.Y+(seg.B.Y-seg.A.Y)*t, seg.A.X+(seg.B.X-seg.A.X)*t)
+	}
+	// a sample for every degree
+	n := int(math.Ceil(math.Max(math.Abs(seg.B.X-seg.A.X),
+		math.Abs(seg.B.Y-seg.A.Y))))
+	if n < 8 {
+		n = 8
+	}
+	best, bestH := 0, math.Inf(1)
+	for i := 0; i <= n; i++ {
+		h := at(float64(i) / float64(n))
+		if !far && h <= g.haversine {
+			return true
+		}
+		if far && -h > g.haversine {
+			return false
+		}
+		if h < bestH {
+			best, bestH = i, h
+		}
+	}
+	lo := math.Max(float64(best-1), 0) / float64(n)
+	hi := math.Min(float64(best+1), float64(n)) / float64(n)
+	for i := 0; i < 64 && hi-lo > 1e-12; i++ {
+		m1, m2 := lo+(hi-lo)/3, hi-(hi-lo)/3
+		if at(m1) < at(m2) {
+			hi = m2
+		} else {
+			lo = m1
+		}
+	}
+	return sign*at((lo+hi)/2) <= g.haversine
+}
+
+// searchRects returns the rectangles that hold all of the circle. They're
+// made from its true extent, rather than from the polygon, which is a little
+// smaller than the circle. A circle that crosses the antimeridian has a
+// rectangle on each side of it.
+func (g *Circle) searchRects() []geometry.Rect {
+	// the radius in degrees, which is padded for rounding
+	radius := geo.NormalizeDistance(g.meters)/geo.DistanceTo(0, 0, 1, 0) + 1e-9
+	minY, maxY := g.center.Y-radius, g.center.Y+radius
+	if minY <= -90 || maxY >= 90 {
+		// a pole is in the circle, so it has every longitude
+		return []geometry.Rect{{
+			Min: geometry.Point{X: -180, Y: math.Max(minY, -90)},
+			Max: geometry.Point{X: 180, Y: math.Min(maxY, 90)},
+		}}
+	}
+	sin := math.Sin(radius*math.Pi/180) / math.Cos(g.center.Y*math.Pi/180)
+	lon := math.Asin(math.Min(sin, 1)) * 180 / math.Pi
+	minX, maxX := g.center.X-lon, g.center.X+lon
+	switch {
+	case maxX-minX >= 360:
+		minX, maxX = -180, 180
+	case minX < -180:
+		return []geometry.Rect{
+			{Min: geometry.Point{X: minX + 360, Y: minY},
+				Max: geometry.Point{X: 180, Y: maxY}},
+			{Min: geometry.Point{X: -180, Y: minY},
+				Max: geometry.Point{X: maxX, Y: maxY}},
+		}
+	case maxX > 180:
+		return []geometry.Rect{
+			{Min: geometry.Point{X: minX, Y: minY},
+				Max: geometry.Point{X: 180, Y: maxY}},
+			{Min: geometry.Point{X: -180, Y: minY},
+				Max: geometry.Point{X: maxX - 360, Y: maxY}},
+		}
+	}
+	return []geometry.Rect{{
+		Min: geometry.Point{X: minX, Y: minY},
+		Max: geometry.Point{X: maxX, Y: maxY},
+	}}
+}
+
+// convex returns true if the circle's radius is no more than a quarter of
+// the way around the earth.
+func (g *Circle) convex() bool {
+	return geo.NormalizeDistance(g.meters) <= geo.DistanceTo(0, 0, 0, 90)
+}
+
 // Contains returns true if the circle contains other object
 func (g *Circle) Contains(obj Object) bool {
 	switch other := obj.(type) {
@@ -95,6 +421,20 @@ func (g *Circle) Contains(obj Object) bool {
 		return g.containsPoint(other.Center())
 	case *Circle:
 		return other.Distance(g) < (other.meters + g.meters)
+	case *LineString:
+		if g.convex() {
+			return g.containsSeries(&other.base)
+		}
+	case *Polygon:
+		if g.convex() {
+			return g.containsSeries(other.base.Exterior)
+		}
+	case *Rect:
+		if g.convex() {
+			return g.containsSeries(other.base)
+		}
+	case *Feature:
+		return g.Contains(other.base)
 	case Collection:
 		for _, p := range other.Children() {
 			if !g.Contains(p) {
@@ -102,10 +442,9 @@ func (g *Circle) Contains(obj Object) bool {
 			}
 		}
 		return true
-	default:
-		// No simple cases, so using polygon approximation.
-		return g.getObject().Contains(other)
 	}
+	// No simple cases, so using polygon approximation.
+	return g.getObject().Contains(obj)
 }
 
 // Intersects returns true the circle intersects other object
@@ -113,6 +452,8 @@ func (g *Circle) Intersects(obj Object) bool {
 	switch other := obj.(type) {
 	case *Point:
 		return g.containsPoint(other.Center())
+	case *SimplePoint:
+		return g.containsPoint(other.Center())
 	case *Circle:
 		return other.Distance(g) <= (other.meters + g.meters)
 	case Collection:
@@ -124,6 +465,21 @@ func (g *Circle) Intersects(obj Object) bool {
 		return false
 	case *Feature:
 		return g.Intersects(other.base)
+	case *LineString:
+		return g.nearSeries(&other.base)
+	case *Polygon:
+		if other.base.ContainsPoint(g.center) ||
+			g.nearSeries(other.base.Exterior) {
+			return true
+		}
+		for _, hole := range other.base.Holes {
+			if g.nearSeries(hole) {
+				return true
+			}
+		}
+		return false
+	case *Rect:
+		return other.base.ContainsPoint(g.center) || g.nearSeries(other.base)
 	default:
 		// No simple cases, so using polygon approximation.
 		return g.getObject().Intersects(obj)
@@ -147,6 +503,87 @@ func (g *Circle) NumPoints() int {
 	return 1
 }
 
+// Centroid returns the center of the circle.
+func (g *Circle) Centroid() geometry.Point {
+	return g.center
+}
+
+// PointOnSurface returns the center of the circle.
+func (g *Circle) PointOnSurface() geometry.Point {
+	return g.center
+}
+
+// PoleOfInaccessibility returns the center of the circle.
+func (g *Circle) PoleOfInaccessibility(precision float64) geometry.Point {
+	return g.center
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *Circle) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *Circle) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *Circle) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *Circle) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *Circle) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *Circle) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *Circle) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *Circle) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the circle.
+func (g *Circle) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the circle.
+func (g *Circle) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the circle.
+func (g *Circle) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the circle to a point.
+func (g *Circle) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the circle and obj.
+func (g *Circle) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *Circle) Distance(other Object) float64 {
 	return g.getObject().Distance(other)
 }
@@ -164,11 +601,23 @@ func (g *Circle) Polygon() Object {
 	return g.getObject()
 }
 
+// Steps returns the number of points in the polygon that approximates the
+// circle.
+func (g *Circle) Steps() int {
+	return g.steps
+}
+
+// getObject returns the polygon that approximates the circle. It's made the
+// first time that it's needed, and is safe to call from many goroutines.
 func (g *Circle) getObject() Object {
-	if g.object != nil {
-		return g.object
+	if g.cache == nil {
+		// not made by NewCircle
+		return makeCircleObject(g.center, g.meters, g.steps)
 	}
-	return makeCircleObject(g.center, g.meters, g.steps)
+	g.cache.once.Do(func() {
+		g.cache.object = makeCircleObject(g.center, g.meters, g.steps)
+	})
+	return g.cache.object
 }
 
 func makeCircleObject(center geometry.Point, meters float64, steps int) Object {
@@ -182,36 +631,56 @@ func makeCircleObject(center geometry.Point, meters float64, steps int) Object {
 		return gPoly
 	}
 	meters = geo.NormalizeDistance(meters)
-	points := make([]geometry.Point, 0, steps+1)
-
-	// calc the four corners
-	maxY, _ := geo.DestinationPoint(center.Y, center.X, meters, 0)
-	_, maxX := geo.DestinationPoint(center.Y, center.X, meters, 90)
-	minY, _ := geo.DestinationPoint(center.Y, center.X, meters, 180)
-	_, minX := geo.DestinationPoint(center.Y, center.X, meters, 270)
-
-	// TODO: detect of pole and antimeridian crossing and generate a
-	// valid multigeometry
-
-	// use the half width of the lat and lon
-	lons := (maxX - minX) / 2
-	lats := (maxY - minY) / 2
+	gopts := &geometry.IndexOptions{Kind: geometry.None}
+	northPole := geo.DistanceTo(center.Y, center.X, 90, center.X) < meters
+	southPole := geo.DistanceTo(center.Y, center.X, -90, center.X) < meters
+	if northPole && southPole {
+		// covers the whole earth
+		return NewPolygon(&geometry.Poly{Exterior: geometry.Rect{
+			Min: geometry.Point{X: -180, Y: -90},
+			Max: geometry.Point{X: 180, Y: 90},
+		}})
+	}
 
-	// generate the
-	for th := 0.0; th <= 360.0; th += 360.0 / float64(steps) {
-		radians := (math.Pi / 180) * th
-		x := center.X + lons*math.Cos(radians)
-		y := center.Y + lats*math.Sin(radians)
-		points = append(points, geometry.Point{X: x, Y: y})
+	// generate the points counter-clockwise, starting from the east
+	points := make([]geometry.Point, 0, steps+1)
+	for i := 0; i < steps; i++ {
+		bearing := 90 - 360*float64(i)/float64(steps)
+		lat, lon := geo.DestinationPoint(center.Y, center.X, meters, bearing)
+		points = append(points, geometry.Point{X: lon, Y: lat})
 	}
 	// add last connecting point, make a total of steps+1
 	points = append(points, points[0])
 
-	return NewPolygon(
-		geometry.NewPoly(points, nil, &geometry.IndexOptions{
-			Kind: geometry.None,
-		}),
-	)
+	var polys []*geometry.Poly
+	switch {
+	case northPole || southPole:
+		// The points go all the way around the pole, so the unwrapped ring
+		// ends 360 degrees from where it started. It's closed by going along
+		// the pole, and is then split at the antimeridian.
+		poleY := 90.0
+		if southPole {
+			poleY = -90
+		}
+		ring := unwrapPoints(points)
+		first, last := ring[0], ring[len(ring)-1]
+		ring = append(ring,
+			geometry.Point{X: last.X, Y: poleY},
+			geometry.Point{X: first.X, Y: poleY},
+			first,
+		)
+		polys, _ = splitUnwrappedPoly(geometry.NewPoly(ring, nil, nil), nil,
+			gopts)
+	case crossesAntimeridian(points):
+		polys, _ = splitAntimeridianPoly([][]geometry.Point{points}, nil,
+			gopts)
+	default:
+		return NewPolygon(geometry.NewPoly(points, nil, gopts))
+	}
+	if len(polys) == 1 {
+		return NewPolygon(polys[0])
+	}
+	return NewMultiPolygon(polys)
 }
 
 func (g *Circle) Members() string {
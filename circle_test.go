@@ -1,6 +1,8 @@
 package geojson
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/tidwall/geojson/geometry"
@@ -179,3 +181,211 @@ func TestPointCircle(t *testing.T) {
 		t.Fatal("expected true")
 	}
 }
+
+func TestCircleAntimeridian(t *testing.T) {
+	circle := NewCircle(P(179.5, 0), 200000, 64)
+	multi, ok := circle.Polygon().(*MultiPolygon)
+	expect(t, ok && len(multi.children) == 2)
+	expect(t, circle.Rect().Min.X == -180 && circle.Rect().Max.X == 180)
+	expect(t, circle.Contains(RO(179.4, -0.1, 179.6, 0.1)))
+	expect(t, circle.Contains(RO(-179.6, -0.1, -179.4, 0.1)))
+	expect(t, circle.Intersects(RO(-179.9, -0.5, -179.7, 0.5)))
+	expect(t, !circle.Intersects(RO(-175, -0.5, -174, 0.5)))
+	expect(t, !circle.Intersects(RO(170, -0.5, 171, 0.5)))
+	expect(t, circle.Valid())
+}
+
+func TestCirclePole(t *testing.T) {
+	circle := NewCircle(P(0, 89), 300000, 64)
+	expect(t, circle.Polygon().Rect().Max.Y == 90)
+	expect(t, circle.Contains(PO(0, 90)))
+	expect(t, circle.Contains(RO(-10, 89.5, 10, 89.9)))
+	expect(t, circle.Contains(RO(170, 88.5, 171, 88.7)))
+	expect(t, circle.Intersects(RO(-180, 88.4, -179, 88.6)))
+	expect(t, !circle.Intersects(RO(170, 87, 171, 87.5)))
+
+	circle = NewCircle(P(45, -88), 500000, 64)
+	expect(t, circle.Polygon().Rect().Min.Y == -90)
+	expect(t, circle.Contains(RO(-135, -89.9, -134, -89.5)))
+	expect(t, !circle.Intersects(RO(-135, -87, -134, -86)))
+
+	world := NewCircle(P(0, 0), 20000000, 64)
+	expect(t, world.Contains(RO(-180, -90, 180, 90)))
+}
+
+func rectPolygon(minX, minY, maxX, maxY float64) *Polygon {
+	return NewPolygon(geometry.NewPoly(seriesPoints(R(minX, minY, maxX, maxY)),
+		nil, nil))
+}
+
+func TestCircleExact(t *testing.T) {
+	for _, steps := range []int{3, 8, 64} {
+		circle := NewCircle(P(0, 0), 1000, steps)
+		// the line passes 890 meters from the center
+		expect(t, circle.Intersects(LO([]geometry.Point{
+			P(-1, 0.008), P(1, 0.008)})))
+		// the line passes 1056 meters from the center
+		expect(t, !circle.Intersects(LO([]geometry.Point{
+			P(-1, 0.0095), P(1, 0.0095)})))
+		expect(t, circle.Intersects(RO(0.008, -1, 1, 1)))
+		expect(t, !circle.Intersects(RO(0.0095, -1, 1, 1)))
+		expect(t, circle.Intersects(RO(-1, -1, 1, 1)))
+		expect(t, circle.Intersects(rectPolygon(-1, -1, 1, 1)))
+		expect(t, circle.Intersects(rectPolygon(0.008, -1, 1, 1)))
+		expect(t, !circle.Intersects(rectPolygon(0.0095, -1, 1, 1)))
+		// the center is in the hole
+		hole := []geometry.Point{
+			P(-0.0095, -0.0095), P(0.0095, -0.0095), P(0.0095, 0.0095),
+			P(-0.0095, 0.0095), P(-0.0095, -0.0095)}
+		expect(t, !circle.Intersects(NewPolygon(geometry.NewPoly(
+			seriesPoints(R(-1, -1, 1, 1)), [][]geometry.Point{hole}, nil))))
+
+		// the corners are 943 meters from the center
+		expect(t, circle.Contains(RO(-0.006, -0.006, 0.006, 0.006)))
+		expect(t, circle.Contains(rectPolygon(-0.006, -0.006, 0.006, 0.006)))
+		expect(t, !circle.Contains(RO(-0.0065, -0.0065, 0.0065, 0.0065)))
+		expect(t, circle.Contains(LO([]geometry.Point{
+			P(-0.006, -0.006), P(0.006, 0.006)})))
+	}
+}
+
+func TestCirclePlanarSegments(t *testing.T) {
+	// segments are straight in longitude and latitude, not great circles
+	line := LO([]geometry.Point{P(-60, 70), P(60, 70)})
+	expect(t, NewCircle(P(0, 70), 50000, 64).Intersects(line))
+	expect(t, !NewCircle(P(0, 80), 50000, 64).Intersects(line))
+	expect(t, !NewCircle(P(0, 79.7), 50000, 64).Intersects(rectPolygon(-60, 60,
+		60, 70)))
+	line = LO([]geometry.Point{P(170, 0), P(-170, 0)})
+	expect(t, NewCircle(P(0, 0.2), 50000, 64).Intersects(line))
+	expect(t, !NewCircle(P(180, 0.2), 50000, 64).Intersects(line))
+
+	// the middle of a segment can be farther away than either end
+	circle := NewCircle(P(180, 80), 12*111195, 64)
+	expect(t, circle.Contains(PO(-170, 85)) && circle.Contains(PO(170, 85)))
+	expect(t, !circle.Contains(PO(0, 85)))
+	expect(t, !circle.Contains(LO([]geometry.Point{P(-170, 85), P(170, 85)})))
+	expect(t, !circle.Contains(RO(-170, 84, 170, 86)))
+	expect(t, !circle.Contains(rectPolygon(-170, 84, 170, 86)))
+	expect(t, circle.Contains(LO([]geometry.Point{P(175, 85), P(179, 85)})))
+	expect(t, circle.Contains(RO(175, 84, 179, 86)))
+}
+
+func TestCircleSymmetric(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	rnd := func(n float64) float64 { return (r.Float64()*2 - 1) * n }
+	for i := 0; i < 2000; i++ {
+		circle := NewCircle(P(rnd(180), rnd(85)), r.Float64()*500000, 64)
+		x, y := rnd(180), rnd(85)
+		var objs []Object
+		objs = append(objs,
+			LO([]geometry.Point{P(x, y), P(x+rnd(20), y+rnd(5))}),
+			RO(x, y, x+r.Float64()*5, y+r.Float64()*5),
+			rectPolygon(x, y, x+r.Float64()*5, y+r.Float64()*5),
+			PO(x, y),
+		)
+		for _, obj := range objs {
+			expect(t, circle.Intersects(obj) == obj.Intersects(circle))
+		}
+	}
+	circle := NewCircle(P(180, 0), 50000, 64)
+	line := LO([]geometry.Point{P(170, 0), P(-170, 0)})
+	expect(t, circle.Intersects(line) == line.Intersects(circle))
+}
+
+func TestCircleCache(t *testing.T) {
+	lazy := NewCircleWithOptions(P(-112, 33), 5000, nil)
+	expect(t, lazy.Steps() == 64)
+	expect(t, lazy.cache.object == nil)
+	rect := lazy.Rect()
+	expect(t, lazy.cache.object != nil)
+	expect(t, lazy.Polygon() == lazy.Polygon())
+	expect(t, lazy.Rect() == rect)
+
+	eager := NewCircleWithOptions(P(-112, 33), 5000,
+		&CircleOptions{Steps: 16, Eager: true})
+	expect(t, eager.cache.object != nil)
+	expect(t, eager.Polygon().NumPoints() == 17)
+
+	circle := NewCircle(P(-112, 33), 5000, 64)
+	objs := make(chan Object, 8)
+	for i := 0; i < cap(objs); i++ {
+		go func() { objs <- circle.Polygon() }()
+	}
+	first := <-objs
+	for i := 1; i < cap(objs); i++ {
+		expect(t, <-objs == first)
+	}
+
+	// zero steps are the default
+	eager = NewCircleWithOptions(P(-112, 33), 5000, &CircleOptions{Eager: true})
+	expect(t, eager.Steps() == 64)
+	expect(t, eager.Polygon().NumPoints() == 65)
+	expect(t, NewCircleWithOptions(P(-112, 33), 5000,
+		&CircleOptions{Steps: 2}).Steps() == 3)
+	expect(t, NewCircle(P(-112, 33), 5000, 0).Steps() == 3)
+
+	g, err := Parse(
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{"type":"Circle","radius":5000}}`,
+		&ParseOptions{CircleOptions: &CircleOptions{Steps: 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, g.(*Circle).Steps() == 8)
+	expect(t, g.(*Circle).Polygon().NumPoints() == 9)
+	g, err = Parse(
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{"type":"Circle","radius":5000}}`,
+		&ParseOptions{CircleOptions: &CircleOptions{Eager: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, g.(*Circle).Steps() == 64)
+}
+
+func TestCircleUnits(t *testing.T) {
+	for _, units := range []string{"m", "km", "mi", "ft", "yd", "nm"} {
+		expectJSON(t,
+			`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{"type":"Circle","radius":5,"radius_units":"`+units+`"}}`,
+			nil,
+		)
+	}
+	expectJSON(t,
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-112,33]},"properties":{"type":"Circle","radius":5,"radius_units":"furlongs"}}`,
+		errCircleRadiusUnitsInvalid,
+	)
+
+	circle, err := NewCircleWithUnits(P(-112, 33), 5, NauticalMiles, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, circle.Meters() == 9260)
+	expect(t, circle.Units() == NauticalMiles)
+	expect(t, circle.Radius(NauticalMiles) == 5)
+	expect(t, circle.Radius(Meters) == 9260)
+	expect(t, circle.Radius(Kilometers) == 9.26)
+	expect(t, circle.Radius("furlongs") == 0)
+	expect(t, circle.Contains(PO(-112, 33.08)))
+	expect(t, !circle.Contains(PO(-112, 33.09)))
+
+	circle, err = NewCircleWithUnits(P(-112, 33), 1000, Feet, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, circle.Meters() == 304.8)
+	expect(t, circle.Radius(Feet) == 1000)
+	expect(t, math.Abs(circle.Radius(Yards)-1000.0/3) < 1e-9)
+	data, err := circle.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var circle2 Circle
+	if err := circle2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, circle2.String() == circle.String())
+	expect(t, circle2.Meters() == circle.Meters())
+
+	_, err = NewCircleWithUnits(P(-112, 33), 5, "furlongs", nil)
+	expect(t, err == errCircleRadiusUnitsInvalid)
+	expect(t, NewCircle(P(-112, 33), 5000, 64).Units() == Meters)
+}
@@ -0,0 +1,67 @@
+package geojson
+
+import "github.com/tidwall/geojson/geometry"
+
+// clipParts returns the parts of an object that are inside of a rectangle.
+func clipParts(obj Object, rect geometry.Rect) (
+	points []geometry.Point, lines []*geometry.Line, polys []*geometry.Poly,
+) {
+	for _, part := range appendObjectParts(nil, obj, 0, true) {
+		switch {
+		case part.poly != nil:
+			if poly := part.poly.ClipToRect(rect); poly != nil {
+				polys = append(polys, poly)
+			}
+		case part.line != nil:
+			for _, pts := range part.line.ClipToRect(rect) {
+				lines = append(lines,
+					geometry.NewLine(pts, geometry.DefaultIndexOptions))
+			}
+		default:
+			if rect.ContainsPoint(part.point) {
+				points = append(points, part.point)
+			}
+		}
+	}
+	return points, lines, polys
+}
+
+// ClipToRect returns the parts of the line that are inside of a rectangle.
+// The result is a LineString when there is exactly one part, otherwise it's
+// a MultiLineString.
+func (g *LineString) ClipToRect(rect geometry.Rect) Object {
+	_, lines, _ := clipParts(g, rect)
+	if len(lines) == 1 {
+		return NewLineString(lines[0])
+	}
+	return NewMultiLineString(lines)
+}
+
+// ClipToRect returns the part of the polygon that is inside of a rectangle.
+// The result is an empty MultiPolygon when no part is inside.
+func (g *Polygon) ClipToRect(rect geometry.Rect) Object {
+	_, _, polys := clipParts(g, rect)
+	if len(polys) == 1 {
+		return NewPolygon(polys[0])
+	}
+	return NewMultiPolygon(polys)
+}
+
+// ClipToRect returns the points that are inside of a rectangle.
+func (g *MultiPoint) ClipToRect(rect geometry.Rect) Object {
+	points, _, _ := clipParts(g, rect)
+	return NewMultiPoint(points)
+}
+
+// ClipToRect returns the parts of the lines that are inside of a rectangle.
+func (g *MultiLineString) ClipToRect(rect geometry.Rect) Object {
+	_, lines, _ := clipParts(g, rect)
+	return NewMultiLineString(lines)
+}
+
+// ClipToRect returns the parts of the polygons that are inside of a
+// rectangle.
+func (g *MultiPolygon) ClipToRect(rect geometry.Rect) Object {
+	_, _, polys := clipParts(g, rect)
+	return NewMultiPolygon(polys)
+}
@@ -0,0 +1,41 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestClipToRect(t *testing.T) {
+	rect := R(0, 0, 10, 10)
+	line := LO([]geometry.Point{P(-5, 5), P(15, 5)})
+	expect(t, line.ClipToRect(rect).JSON() ==
+		`{"type":"LineString","coordinates":[[0,5],[10,5]]}`)
+	u := LO([]geometry.Point{P(0, 10), P(0, 0), P(10, 0), P(10, 10)})
+	expect(t, u.ClipToRect(R(-1, 5, 11, 11)).JSON() ==
+		`{"type":"MultiLineString","coordinates":[[[0,10],[0,5]],[[10,5],[10,10]]]}`)
+	expect(t, line.ClipToRect(R(20, 20, 30, 30)).Empty())
+
+	poly := expectJSON(t, `{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`, nil).(*Polygon)
+	expect(t, poly.ClipToRect(R(5, 5, 15, 15)).JSON() ==
+		`{"type":"Polygon","coordinates":[[[5,5],[10,5],[10,10],[5,10],[5,5]]]}`)
+	expect(t, poly.ClipToRect(R(20, 20, 30, 30)).JSON() ==
+		`{"type":"MultiPolygon","coordinates":[]}`)
+
+	mp := expectJSON(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[10,0],[10,10],[0,10],[0,0]]],
+		[[[20,0],[30,0],[30,10],[20,10],[20,0]]]]}`, nil).(*MultiPolygon)
+	expect(t, len(mp.ClipToRect(R(5, 0, 25, 10)).(*MultiPolygon).Children()) == 2)
+	expect(t, mp.ClipToRect(R(5, 0, 25, 10)).(*MultiPolygon).Area() == 100)
+	expect(t, len(mp.ClipToRect(R(0, 0, 15, 10)).(*MultiPolygon).Children()) == 1)
+
+	mls := expectJSON(t, `{"type":"MultiLineString","coordinates":[
+		[[-5,5],[15,5]],[[20,20],[30,30]]]}`, nil).(*MultiLineString)
+	expect(t, mls.ClipToRect(rect).JSON() ==
+		`{"type":"MultiLineString","coordinates":[[[0,5],[10,5]]]}`)
+
+	mpo := MPO([]geometry.Point{P(5, 5), P(15, 15), P(10, 10)})
+	expect(t, mpo.ClipToRect(rect).JSON() ==
+		`{"type":"MultiPoint","coordinates":[[5,5],[10,10]]}`)
+}
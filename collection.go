@@ -1,6 +1,9 @@
 package geojson
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/tidwall/geojson/geometry"
 	"github.com/tidwall/rtree"
 )
@@ -11,18 +14,57 @@ type collection struct {
 	tree     *rtree.RTree
 	prect    geometry.Rect
 	pempty   bool
+	index    int  // the IndexChildren option
+	owned    bool // children may be appended to in place
+	// mu guards children, tree, prect, and pempty, which change when a
+	// FeatureCollection or GeometryCollection is changed. The children slice
+	// is never changed in place, so a slice that's returned stays the same,
+	// and neither is a tree that's been returned by snapshot.
+	mu *sync.RWMutex
+	// shared is set when the tree is walked outside of the lock, so that the
+	// next change copies the tree rather than changing it in place.
+	shared int32
+}
+
+func (g *collection) rlock() {
+	if g.mu != nil {
+		g.mu.RLock()
+	}
+}
+
+func (g *collection) runlock() {
+	if g.mu != nil {
+		g.mu.RUnlock()
+	}
+}
+
+// snapshot returns the index and the children as they are now. Neither is
+// changed after it's returned, so they can be walked without a lock.
+func (g *collection) snapshot() (*rtree.RTree, []Object) {
+	g.rlock()
+	defer g.runlock()
+	if g.tree != nil {
+		atomic.StoreInt32(&g.shared, 1)
+	}
+	return g.tree, g.children
 }
 
 func (g *collection) Indexed() bool {
+	g.rlock()
+	defer g.runlock()
 	return g.tree != nil
 }
 
 func (g *collection) Children() []Object {
-	return g.children
+	g.rlock()
+	defer g.runlock()
+	// the capacity is limited, so that appending to the slice doesn't write
+	// to the collection's array
+	return g.children[:len(g.children):len(g.children)]
 }
 
 func (g *collection) ForEach(iter func(geom Object) bool) bool {
-	for _, child := range g.children {
+	for _, child := range g.Children() {
 		if !child.ForEach(iter) {
 			return false
 		}
@@ -31,12 +73,13 @@ func (g *collection) ForEach(iter func(geom Object) bool) bool {
 }
 
 func (g *collection) Base() []Object {
-	return g.children
+	return g.Children()
 }
 
 func (g *collection) Search(rect geometry.Rect, iter func(child Object) bool) {
-	if g.tree != nil {
-		g.tree.Search(
+	tree, children := g.snapshot()
+	if tree != nil {
+		tree.Search(
 			[2]float64{rect.Min.X, rect.Min.Y},
 			[2]float64{rect.Max.X, rect.Max.Y},
 			func(_, _ [2]float64, value interface{}) bool {
@@ -44,7 +87,7 @@ func (g *collection) Search(rect geometry.Rect, iter func(child Object) bool) {
 			},
 		)
 	} else {
-		for _, child := range g.children {
+		for _, child := range children {
 			if child.Empty() {
 				continue
 			}
@@ -57,7 +100,86 @@ func (g *collection) Search(rect geometry.Rect, iter func(child Object) bool) {
 	}
 }
 
+// searchRects returns the rectangles to search for the children that may
+// intersect an object. A circle or collection that is split at the
+// antimeridian has a rectangle for each part, rather than one that goes all
+// the way around the earth.
+func searchRects(obj Object) []geometry.Rect {
+	switch g := obj.(type) {
+	case *Circle:
+		return g.searchRects()
+	case *Feature:
+		return searchRects(g.base)
+	}
+	if col, ok := obj.(Collection); ok {
+		var rects []geometry.Rect
+		for _, child := range col.Children() {
+			if !child.Empty() {
+				rects = append(rects, searchRects(child)...)
+			}
+		}
+		return rects
+	}
+	if obj.Empty() {
+		return nil
+	}
+	return []geometry.Rect{obj.Rect()}
+}
+
+// searchObject iterates over the children whose rectangles intersect an
+// object's search rectangles, and that match a predicate.
+func (g *collection) searchObject(obj Object, match func(child Object) bool,
+	iter func(child Object) bool,
+) {
+	rects := searchRects(obj)
+	var seen map[Object]bool
+	if len(rects) > 1 {
+		seen = make(map[Object]bool)
+	}
+	for _, rect := range rects {
+		stopped := false
+		g.Search(rect, func(child Object) bool {
+			if seen != nil {
+				if seen[child] {
+					return true
+				}
+				seen[child] = true
+			}
+			if match(child) && !iter(child) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// SearchIntersects iterates over the children that intersect an object. The
+// index is used to find the children that may intersect, which are then
+// tested exactly.
+func (g *collection) SearchIntersects(obj Object,
+	iter func(child Object) bool,
+) {
+	g.searchObject(obj, func(child Object) bool {
+		return obj.Intersects(child)
+	}, iter)
+}
+
+// SearchWithin iterates over the children that are within an object. The
+// index is used to find the children that may be within, which are then
+// tested exactly.
+func (g *collection) SearchWithin(obj Object, iter func(child Object) bool) {
+	g.searchObject(obj, func(child Object) bool {
+		return obj.Contains(child)
+	}, iter)
+}
+
 func (g *collection) Empty() bool {
+	g.rlock()
+	defer g.runlock()
 	return g.pempty
 }
 
@@ -66,6 +188,8 @@ func (g *collection) Valid() bool {
 }
 
 func (g *collection) Rect() geometry.Rect {
+	g.rlock()
+	defer g.runlock()
 	return g.prect
 }
 
@@ -78,6 +202,26 @@ func (g *collection) AppendJSON(dst []byte) []byte {
 	return append(dst, "null"...)
 }
 
+// AppendWKT appends the Well-Known Text to dst as a GEOMETRYCOLLECTION.
+func (g *collection) AppendWKT(dst []byte) []byte {
+	return appendWKTGeometryCollection(dst, g.Children())
+}
+
+// WKT returns the Well-Known Text representation
+func (g *collection) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary to dst as a GeometryCollection.
+func (g *collection) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *collection) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *collection) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -86,6 +230,12 @@ func (g *collection) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation as a
+// GeometryCollection.
+func (g *collection) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
 func (g *collection) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -140,7 +290,7 @@ func (g *collection) WithinRect(rect geometry.Rect) bool {
 		}
 		return false
 	})
-	return withinCount == len(g.children)
+	return withinCount == len(g.Children())
 }
 
 func (g *collection) WithinPoint(point geometry.Point) bool {
@@ -155,7 +305,7 @@ func (g *collection) WithinPoint(point geometry.Point) bool {
 		}
 		return false
 	})
-	return withinCount == len(g.children)
+	return withinCount == len(g.Children())
 }
 
 func (g *collection) WithinLine(line *geometry.Line) bool {
@@ -170,7 +320,7 @@ func (g *collection) WithinLine(line *geometry.Line) bool {
 		}
 		return false
 	})
-	return withinCount == len(g.children)
+	return withinCount == len(g.Children())
 }
 
 func (g *collection) WithinPoly(poly *geometry.Poly) bool {
@@ -185,7 +335,7 @@ func (g *collection) WithinPoly(poly *geometry.Poly) bool {
 		}
 		return false
 	})
-	return withinCount == len(g.children)
+	return withinCount == len(g.Children())
 }
 
 func (g *collection) Intersects(obj Object) bool {
@@ -258,13 +408,17 @@ func (g *collection) IntersectsPoly(poly *geometry.Poly) bool {
 
 func (g *collection) NumPoints() int {
 	var n int
-	for _, child := range g.children {
+	for _, child := range g.Children() {
 		n += child.NumPoints()
 	}
 	return n
 }
 
 func (g *collection) parseInitRectIndex(opts *ParseOptions) {
+	g.index = opts.IndexChildren
+	if g.mu == nil {
+		g.mu = new(sync.RWMutex)
+	}
 	g.pempty = true
 	var count int
 	for _, child := range g.children {
@@ -286,35 +440,303 @@ func (g *collection) parseInitRectIndex(opts *ParseOptions) {
 		count++
 	}
 	if count > 0 && opts.IndexChildren != 0 && count >= opts.IndexChildren {
-		g.tree = new(rtree.RTree)
-		for _, child := range g.children {
-			if child.Empty() {
-				continue
+		g.buildIndex()
+	}
+}
+
+func (g *collection) buildIndex() {
+	g.tree = new(rtree.RTree)
+	atomic.StoreInt32(&g.shared, 0)
+	for _, child := range g.children {
+		g.indexInsert(child)
+	}
+}
+
+// ownTree copies the index when it may be walked by a snapshot, so that it
+// can be changed. The collection must be write locked.
+func (g *collection) ownTree() {
+	if g.tree == nil || atomic.LoadInt32(&g.shared) == 0 {
+		return
+	}
+	tree := new(rtree.RTree)
+	g.tree.Scan(func(min, max [2]float64, value interface{}) bool {
+		tree.Insert(min, max, value)
+		return true
+	})
+	g.tree = tree
+	atomic.StoreInt32(&g.shared, 0)
+}
+
+func (g *collection) indexInsert(child Object) {
+	if g.tree == nil || child.Empty() {
+		return
+	}
+	rect := child.Rect()
+	g.tree.Insert(
+		[2]float64{rect.Min.X, rect.Min.Y},
+		[2]float64{rect.Max.X, rect.Max.Y},
+		child,
+	)
+}
+
+func (g *collection) indexDelete(child Object) {
+	if g.tree == nil || child.Empty() {
+		return
+	}
+	rect := child.Rect()
+	g.tree.Delete(
+		[2]float64{rect.Min.X, rect.Min.Y},
+		[2]float64{rect.Max.X, rect.Max.Y},
+		child,
+	)
+}
+
+// growRect adds the rectangle of a new child to the collection's rectangle.
+func (g *collection) growRect(child Object) {
+	if child.Empty() {
+		return
+	}
+	if g.pempty {
+		g.prect = child.Rect()
+		g.pempty = false
+	} else {
+		g.prect = unionRects(g.prect, child.Rect())
+	}
+}
+
+// resetRect makes the collection's rectangle again from all of its children.
+func (g *collection) resetRect() {
+	g.prect = geometry.Rect{}
+	g.pempty = true
+	for _, child := range g.children {
+		g.growRect(child)
+	}
+}
+
+// onRectEdge returns true if a child touches the edge of the collection's
+// rectangle, which may shrink when the child is removed.
+func (g *collection) onRectEdge(child Object) bool {
+	if child.Empty() {
+		return false
+	}
+	rect := child.Rect()
+	return rect.Min.X <= g.prect.Min.X || rect.Min.Y <= g.prect.Min.Y ||
+		rect.Max.X >= g.prect.Max.X || rect.Max.Y >= g.prect.Max.Y
+}
+
+func (g *collection) lock() {
+	if g.mu == nil {
+		// not made by a constructor or parser, so it's not shared yet
+		g.mu = new(sync.RWMutex)
+	}
+	g.mu.Lock()
+}
+
+// copyChildren returns a copy of the children that can be changed without
+// changing a slice that was returned by Children.
+func (g *collection) copyChildren(extra int) []Object {
+	children := make([]Object, len(g.children), len(g.children)+extra)
+	copy(children, g.children)
+	return children
+}
+
+func (g *collection) append(children []Object) {
+	g.lock()
+	defer g.mu.Unlock()
+	if !g.owned {
+		// the first append copies the children, which may belong to the
+		// caller of the constructor
+		g.children = g.copyChildren(len(children))
+		g.owned = true
+	}
+	// Appending past the end of the slice is invisible to readers of the
+	// slice that was there before.
+	g.children = append(g.children, children...)
+	for _, child := range children {
+		g.growRect(child)
+	}
+	if g.tree == nil {
+		if g.index != 0 && len(g.children) >= g.index {
+			var count int
+			for _, child := range g.children {
+				if !child.Empty() {
+					count++
+				}
+			}
+			if count >= g.index {
+				g.buildIndex()
 			}
-			rect := child.Rect()
-			g.tree.Insert(
-				[2]float64{rect.Min.X, rect.Min.Y},
-				[2]float64{rect.Max.X, rect.Max.Y},
-				child,
-			)
 		}
+		return
 	}
+	g.ownTree()
+	for _, child := range children {
+		g.indexInsert(child)
+	}
+}
+
+func (g *collection) remove(index int) Object {
+	g.lock()
+	defer g.mu.Unlock()
+	old := g.children[index]
+	children := g.copyChildren(0)
+	g.children = append(children[:index], children[index+1:]...)
+	g.owned = true
+	g.ownTree()
+	g.indexDelete(old)
+	if g.onRectEdge(old) {
+		g.resetRect()
+	}
+	return old
+}
+
+func (g *collection) replace(index int, child Object) Object {
+	g.lock()
+	defer g.mu.Unlock()
+	old := g.children[index]
+	g.children = g.copyChildren(0)
+	g.children[index] = child
+	g.owned = true
+	g.ownTree()
+	g.indexDelete(old)
+	g.indexInsert(child)
+	if g.onRectEdge(old) {
+		g.resetRect()
+	} else {
+		g.growRect(child)
+	}
+	return old
+}
+
+func (g *collection) filter(keep func(child Object) bool) int {
+	g.lock()
+	defer g.mu.Unlock()
+	var children []Object
+	var removed int
+	for _, child := range g.children {
+		if keep(child) {
+			children = append(children, child)
+		} else {
+			if removed == 0 {
+				g.ownTree()
+			}
+			g.indexDelete(child)
+			removed++
+		}
+	}
+	if removed > 0 {
+		g.children = children
+		g.owned = true
+		g.resetRect()
+	}
+	return removed
+}
+
+// Centroid returns the centroid of the children.
+func (g *collection) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns a point on one of the children.
+func (g *collection) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the pole of the children's polygons.
+func (g *collection) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *collection) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *collection) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *collection) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *collection) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *collection) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *collection) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *collection) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *collection) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the collection.
+func (g *collection) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the collection.
+func (g *collection) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the collection.
+func (g *collection) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the collection to a point.
+func (g *collection) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the collection and obj.
+func (g *collection) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
 }
 
 func (g *collection) Distance(obj Object) float64 {
-	return obj.Spatial().DistancePoint(g.Center())
+	return g.distance(obj.Center(), func(child Object) float64 {
+		return child.Distance(obj)
+	})
 }
 func (g *collection) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return g.distance(point, func(child Object) float64 {
+		return child.Spatial().DistancePoint(point)
+	})
 }
 func (g *collection) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return g.distance(rect.Center(), func(child Object) float64 {
+		return child.Spatial().DistanceRect(rect)
+	})
 }
 func (g *collection) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return g.distance(line.Rect().Center(), func(child Object) float64 {
+		return child.Spatial().DistanceLine(line)
+	})
 }
 func (g *collection) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return g.distance(poly.Rect().Center(), func(child Object) float64 {
+		return child.Spatial().DistancePoly(poly)
+	})
 }
 
 func (g *collection) Members() string {
@@ -134,3 +134,77 @@ func TestCollectionUSASubset(t *testing.T) {
 	})
 
 }
+
+func TestCollectionSearchObject(t *testing.T) {
+	var points []geometry.Point
+	for x := -180.0; x < 180; x += 0.5 {
+		for y := -80.0; y <= 80; y += 0.5 {
+			points = append(points, P(x, y))
+		}
+	}
+	mp := NewMultiPoint(points)
+	expect(t, mp.Indexed())
+	search := func(obj Object, within bool) int {
+		var count int
+		iter := func(child Object) bool {
+			count++
+			if within {
+				expect(t, obj.Contains(child))
+			} else {
+				expect(t, obj.Intersects(child))
+			}
+			return true
+		}
+		if within {
+			mp.SearchWithin(obj, iter)
+		} else {
+			mp.SearchIntersects(obj, iter)
+		}
+		return count
+	}
+	scan := func(obj Object, within bool) int {
+		var count int
+		for _, child := range mp.Children() {
+			if (within && obj.Contains(child)) ||
+				(!within && obj.Intersects(child)) {
+				count++
+			}
+		}
+		return count
+	}
+	for _, obj := range []Object{
+		PPO([]geometry.Point{P(10, 10), P(20, 10), P(15, 20), P(10, 10)}, nil),
+		RO(-30.2, -10.2, -20.2, 0.2),
+		LO([]geometry.Point{P(-100, 40), P(-90, 40), P(-90, 50)}),
+		NewCircle(P(-112, 33), 200000, 64),
+		NewCircle(P(179.5, 0), 200000, 64),
+		NewCircle(P(0, 79), 300000, 64),
+		// the polygons of these are much smaller than the circles
+		NewCircle(P(-112, 33), 200000, 3),
+		NewCircle(P(179.5, 0), 200000, 3),
+		NewCircle(P(0, 79), 300000, 3),
+		NewCircle(P(0, 85), 600000, 3),
+	} {
+		for _, within := range []bool{false, true} {
+			count := search(obj, within)
+			expect(t, count > 0)
+			expect(t, count == scan(obj, within))
+		}
+	}
+
+	// the parts of a circle on each side of the antimeridian are searched
+	// separately
+	rects := searchRects(NewCircle(P(179.5, 0), 200000, 64))
+	expect(t, len(rects) == 2)
+	for _, rect := range rects {
+		expect(t, rect.Max.X-rect.Min.X < 3)
+	}
+
+	// stop early
+	var count int
+	mp.SearchIntersects(RO(-10, -10, 10, 10), func(child Object) bool {
+		count++
+		return count < 5
+	})
+	expect(t, count == 5)
+}
@@ -0,0 +1,268 @@
+package geojson
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/tidwall/gjson"
+)
+
+// Decoder reads the features of a GeoJSON FeatureCollection from a stream,
+// one at a time, so that the whole collection is never in memory.
+type Decoder struct {
+	r      *bufio.Reader
+	opts   *ParseOptions
+	state  int    // where the decoder is in the collection
+	hasArr bool   // the features array was found
+	more   bool   // a feature was read, so a comma is next
+	err    error  // an error in the stream, which stops it
+	buf    []byte // the raw json of a feature
+}
+
+// decoder states
+const (
+	decodeStart    = iota // before the collection
+	decodeFeatures        // in the features array
+	decodeDone            // after the features array
+)
+
+// NewDecoder returns a decoder that reads a FeatureCollection from r. A nil
+// opts uses the DefaultParseOptions.
+func NewDecoder(r io.Reader, opts *ParseOptions) *Decoder {
+	if opts == nil {
+		opts = DefaultParseOptions
+	}
+	return &Decoder{r: bufio.NewReader(r), opts: opts}
+}
+
+// Next returns the next feature in the collection, which is parsed with the
+// decoder's ParseOptions. It's a *Feature, or another Object when the
+// collection has a geometry or a Circle in place of a feature. An error for
+// a feature that can't be parsed doesn't stop the stream, and the next call
+// returns the feature after it. An error in the stream itself, such as
+// invalid JSON between the features, is returned from every call after it.
+// Next returns io.EOF when there are no more features.
+func (d *Decoder) Next() (Object, error) {
+	for d.err == nil {
+		switch d.state {
+		case decodeStart:
+			if d.byte() != '{' {
+				d.fail(errDataInvalid)
+			}
+			d.members(true)
+		case decodeFeatures:
+			raw, ok := d.feature()
+			if !ok {
+				continue
+			}
+			return Parse(string(raw), d.opts)
+		case decodeDone:
+			// the members after the features array
+			d.members(false)
+		}
+	}
+	return nil, d.err
+}
+
+// fail stops the stream with an error, unless it's already stopped.
+func (d *Decoder) fail(err error) {
+	if d.err != nil {
+		return
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	d.err = err
+}
+
+// skip returns the next byte that isn't a space.
+func (d *Decoder) skip() (byte, error) {
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		return c, nil
+	}
+}
+
+// byte returns the next byte that isn't a space, which must be there.
+func (d *Decoder) byte() byte {
+	c, err := d.skip()
+	if err != nil {
+		d.fail(err)
+	}
+	return c
+}
+
+// value reads a json value, and appends it to dst. The first byte of the
+// value has already been read.
+func (d *Decoder) value(dst []byte, c byte) []byte {
+	dst = append(dst, c)
+	switch c {
+	case '"':
+		return d.str(dst)
+	case '{', '[':
+		depth := 1
+		for depth > 0 && d.err == nil {
+			c, err := d.r.ReadByte()
+			if err != nil {
+				d.fail(err)
+				break
+			}
+			dst = append(dst, c)
+			switch c {
+			case '"':
+				dst = d.str(dst)
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		return dst
+	}
+	// a number, true, false, or null
+	if (c < '0' || c > '9') && c != '-' && c != 't' && c != 'f' && c != 'n' {
+		d.fail(errDataInvalid)
+		return dst
+	}
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			d.fail(err)
+			return dst
+		}
+		switch c {
+		case ',', '}', ']', ' ', '\t', '\n', '\r':
+			d.r.UnreadByte()
+			return dst
+		}
+		dst = append(dst, c)
+	}
+}
+
+// str reads the rest of a json string, after the opening quote, and appends
+// it to dst.
+func (d *Decoder) str(dst []byte) []byte {
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			d.fail(err)
+			return dst
+		}
+		dst = append(dst, c)
+		switch c {
+		case '\\':
+			c, err = d.r.ReadByte()
+			if err != nil {
+				d.fail(err)
+				return dst
+			}
+			dst = append(dst, c)
+		case '"':
+			return dst
+		}
+	}
+}
+
+// member reads the key of the next member of the collection. It returns
+// false at the end of the collection.
+func (d *Decoder) member(first bool) (key string, ok bool) {
+	c := d.byte()
+	if c == '}' || d.err != nil {
+		return "", false
+	}
+	if !first {
+		if c != ',' {
+			d.fail(errDataInvalid)
+			return "", false
+		}
+		c = d.byte()
+	}
+	if c != '"' {
+		d.fail(errDataInvalid)
+		return "", false
+	}
+	raw := d.str([]byte{c})
+	if d.byte() != ':' {
+		d.fail(errDataInvalid)
+	}
+	if d.err != nil {
+		return "", false
+	}
+	return gjson.ParseBytes(raw).String(), true
+}
+
+// members reads the members of the collection up to the features array, or
+// to the end of the collection.
+func (d *Decoder) members(first bool) {
+	for d.err == nil {
+		key, ok := d.member(first)
+		if !ok {
+			if d.err == nil && !d.hasArr {
+				d.fail(errFeaturesMissing)
+			}
+			d.end()
+			return
+		}
+		first = false
+		c := d.byte()
+		if key == "features" && !d.hasArr {
+			if c != '[' {
+				d.fail(errFeaturesInvalid)
+			}
+			d.hasArr = true
+			d.state = decodeFeatures
+			return
+		}
+		d.buf = d.value(d.buf[:0], c)
+		if key == "type" && d.err == nil &&
+			gjson.ParseBytes(d.buf).String() != "FeatureCollection" {
+			d.fail(errTypeInvalid)
+		}
+	}
+}
+
+// feature reads the raw json of the next feature. It returns false at the
+// end of the features array.
+func (d *Decoder) feature() ([]byte, bool) {
+	c := d.byte()
+	if c == ']' {
+		d.state = decodeDone
+		return nil, false
+	}
+	if d.more {
+		if c != ',' {
+			d.fail(errDataInvalid)
+			return nil, false
+		}
+		c = d.byte()
+	}
+	d.buf = d.value(d.buf[:0], c)
+	if d.err != nil {
+		return nil, false
+	}
+	d.more = true
+	return d.buf, true
+}
+
+// end checks that there is nothing after the collection, and then stops the
+// stream with io.EOF.
+func (d *Decoder) end() {
+	_, err := d.skip()
+	switch {
+	case err == nil:
+		d.fail(errDataInvalid)
+	case err == io.EOF:
+		if d.err == nil {
+			d.err = io.EOF
+		}
+	default:
+		d.fail(err)
+	}
+}
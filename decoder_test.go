@@ -0,0 +1,132 @@
+package geojson
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func decodeAll(t *testing.T, data string, opts *ParseOptions,
+) (objs []Object, errs []error, end error) {
+	t.Helper()
+	d := NewDecoder(strings.NewReader(data), opts)
+	for i := 0; ; i++ {
+		obj, err := d.Next()
+		if err == io.EOF || (err != nil && obj == nil && d.err != nil) {
+			// the end is returned again
+			_, again := d.Next()
+			expect(t, again == err)
+			return objs, errs, err
+		}
+		objs = append(objs, obj)
+		errs = append(errs, err)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	data := `{"type":"FeatureCollection","bbox":[1,2,5,6],"features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"a ] } \" b"}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1]},"properties":{}} ,
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[5,6]},"properties":{"type":"Circle","radius":10}}
+	],"crs":{"type":"name","properties":{"name":"]}"}}} `
+	objs, errs, end := decodeAll(t, data, nil)
+	expect(t, end == io.EOF)
+	expect(t, len(objs) == 3)
+	expect(t, errs[0] == nil && errs[1] == errCoordinatesInvalid && errs[2] == nil)
+	expect(t, objs[0].(*Feature).Members() ==
+		`{"properties":{"name":"a ] } \" b"}}`)
+	expect(t, objs[1] == nil)
+	_, ok := objs[2].(*Circle)
+	expect(t, ok)
+
+	// the same as Parse
+	fc, err := Parse(strings.Replace(data, "[1]", "[3,4]", 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, _, _ = decodeAll(t, strings.Replace(data, "[1]", "[3,4]", 1), nil)
+	for i, child := range fc.(*FeatureCollection).Children() {
+		expect(t, child.JSON() == objs[i].JSON())
+	}
+
+	// options
+	objs, _, _ = decodeAll(t, data, &ParseOptions{DisableCircleType: true})
+	_, ok = objs[2].(*Feature)
+	expect(t, ok)
+
+	for _, test := range []struct {
+		data  string
+		count int
+		end   error
+	}{
+		{`{"type":"FeatureCollection","features":[]}`, 0, io.EOF},
+		{`{"features":[{"type":"Point","coordinates":[1,2]}]}`, 1, io.EOF},
+		{`{"type":"FeatureCollection","features":[`, 0, io.ErrUnexpectedEOF},
+		{`{"type":"FeatureCollection","features":[{"type":"Point"`, 0,
+			io.ErrUnexpectedEOF},
+		{`{"type":"FeatureCollection","features":[]`, 0, io.ErrUnexpectedEOF},
+		{`{"type":"FeatureCollection"}`, 0, errFeaturesMissing},
+		{`{"type":"FeatureCollection","features":null}`, 0, errFeaturesInvalid},
+		{`{"type":"GeometryCollection","features":[]}`, 0, errTypeInvalid},
+		{`[]`, 0, errDataInvalid},
+		{``, 0, io.ErrUnexpectedEOF},
+		{`{"features":[{"type":"Point","coordinates":[1,2]},]}`, 1,
+			errDataInvalid},
+		{`{"features":[{"type":"Point","coordinates":[1,2]} {}]}`, 1,
+			errDataInvalid},
+		{`{"features":[]} {}`, 0, errDataInvalid},
+		{`{"features":[] "type":"FeatureCollection"}`, 0, errDataInvalid},
+	} {
+		objs, _, end := decodeAll(t, test.data, nil)
+		if len(objs) != test.count || end != test.end {
+			t.Fatalf("%s: expected %d and '%v', got %d and '%v'",
+				test.data, test.count, test.end, len(objs), end)
+		}
+	}
+}
+
+// featureReader is a stream of features that is never all in memory.
+type featureReader struct {
+	count, n int
+	buf      []byte
+}
+
+func (r *featureReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		switch {
+		case r.n == 0:
+			r.buf = []byte(`{"type":"FeatureCollection","features":[`)
+		case r.n <= r.count:
+			if r.n > 1 {
+				r.buf = append(r.buf, ',')
+			}
+			r.buf = append(r.buf, `{"type":"Feature","geometry":`+
+				`{"type":"Point","coordinates":[1,2]},"properties":{}}`...)
+		case r.n == r.count+1:
+			r.buf = []byte(`]}`)
+		default:
+			return 0, io.EOF
+		}
+		r.n++
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestDecoderStream(t *testing.T) {
+	d := NewDecoder(&featureReader{count: 100000}, nil)
+	var count int
+	for {
+		obj, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, obj.Center() == P(1, 2))
+		count++
+	}
+	expect(t, count == 100000)
+}
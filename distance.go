@@ -0,0 +1,210 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+)
+
+// The distance functions below return the geodesic minimum distance in meters
+// between two geometries, which is zero when they intersect. An empty
+// geometry has no edges, so its distance is measured from its center.
+
+func rectPoly(rect geometry.Rect) *geometry.Poly {
+	return &geometry.Poly{Exterior: rect}
+}
+
+func distPointSegment(point geometry.Point, seg geometry.Segment) float64 {
+	return geo.DistanceToSegment(point.Y, point.X,
+		seg.A.Y, seg.A.X, seg.B.Y, seg.B.X)
+}
+
+// nearestSeries returns the index of the segment of a series that is nearest
+// to a point, and the distance to it. The series segment index is searched
+// with a window that grows until it holds a segment that is nearer than the
+// window radius. The index is -1 when the series has no segments.
+func nearestSeries(point geometry.Point, series geometry.Series) (
+	index int, meters float64,
+) {
+	if series.NumPoints() == 0 {
+		return -1, geoDistancePoints(point, series.Rect().Center())
+	}
+	best := geoDistancePoints(point, series.PointAt(0))
+	if series.NumSegments() == 0 {
+		return -1, best
+	}
+	// start with the distance to the series rect
+	rect := series.Rect()
+	near := geometry.Point{
+		X: math.Max(rect.Min.X, math.Min(rect.Max.X, point.X)),
+		Y: math.Max(rect.Min.Y, math.Min(rect.Max.Y, point.Y)),
+	}
+	r := math.Max(geoDistancePoints(point, near), best/64)
+	if r < 1 {
+		r = 1
+	}
+	for best > 0 {
+		if r > best {
+			r = best
+		}
+		minLat, minLon, maxLat, maxLon := geo.RectFromCenter(point.Y, point.X, r)
+		series.Search(geometry.Rect{
+			Min: geometry.Point{X: minLon, Y: minLat},
+			Max: geometry.Point{X: maxLon, Y: maxLat},
+		}, func(seg geometry.Segment, idx int) bool {
+			if dist := distPointSegment(point, seg); dist < best {
+				best = dist
+				index = idx
+			}
+			return best > 0
+		})
+		if best <= r {
+			// every segment nearer than r was in the window
+			break
+		}
+		r *= 4
+	}
+	return index, best
+}
+
+func distPointSeries(point geometry.Point, series geometry.Series) float64 {
+	_, meters := nearestSeries(point, series)
+	return meters
+}
+
+// pairSeries returns the nearest locations between two series that do not
+// intersect, which is always from a vertex of one to a segment of the other.
+func pairSeries(a, b geometry.Series) (na, nb geometry.Nearest, meters float64) {
+	if a.NumPoints() == 0 || b.NumPoints() == 0 {
+		na = geometry.Nearest{Point: a.Rect().Center(), Index: -1}
+		nb = geometry.Nearest{Point: b.Rect().Center(), Index: -1}
+		return na, nb, geoDistancePoints(na.Point, nb.Point)
+	}
+	best := math.Inf(+1)
+	var vertex, index int
+	var swapped bool
+	for i := 0; i < a.NumPoints() && best > 0; i++ {
+		if idx, dist := nearestSeries(a.PointAt(i), b); dist < best {
+			best, vertex, index, swapped = dist, i, idx, false
+		}
+	}
+	for i := 0; i < b.NumPoints() && best > 0; i++ {
+		if idx, dist := nearestSeries(b.PointAt(i), a); dist < best {
+			best, vertex, index, swapped = dist, i, idx, true
+		}
+	}
+	if swapped {
+		a, b = b, a
+	}
+	point := a.PointAt(vertex)
+	na = geometry.Nearest{Point: point, Index: vertexSegment(a, vertex)}
+	nb = nearestOnSeries(point, b, index)
+	if swapped {
+		na, nb = nb, na
+	}
+	return na, nb, best
+}
+
+// vertexSegment returns the index of a segment that starts or ends with the
+// vertex at index, or -1 if the series has no segments.
+func vertexSegment(series geometry.Series, index int) int {
+	if n := series.NumSegments(); index >= n {
+		return n - 1
+	}
+	return index
+}
+
+// nearestOnSeries returns the nearest location to a point on the series
+// segment at index, as returned by nearestSeries.
+func nearestOnSeries(point geometry.Point, series geometry.Series, index int,
+) geometry.Nearest {
+	if index == -1 {
+		return geometry.Nearest{Point: series.PointAt(0), Index: -1}
+	}
+	seg := series.SegmentAt(index)
+	lat, lon, _ := geo.NearestPointOnSegment(point.Y, point.X,
+		seg.A.Y, seg.A.X, seg.B.Y, seg.B.X)
+	return geometry.Nearest{Point: geometry.Point{X: lon, Y: lat}, Index: index}
+}
+
+func distSeriesSeries(a, b geometry.Series) float64 {
+	_, _, meters := pairSeries(a, b)
+	return meters
+}
+
+func distPointLine(point geometry.Point, line *geometry.Line) float64 {
+	return distPointSeries(point, line)
+}
+
+func distPointPoly(point geometry.Point, poly *geometry.Poly) float64 {
+	if poly.Empty() {
+		return geoDistancePoints(point, poly.Rect().Center())
+	}
+	if poly.IntersectsPoint(point) {
+		return 0
+	}
+	best := distPointSeries(point, poly.Exterior)
+	for _, hole := range poly.Holes {
+		best = math.Min(best, distPointSeries(point, hole))
+	}
+	return best
+}
+
+func distLineLine(a, b *geometry.Line) float64 {
+	if !a.Empty() && !b.Empty() && a.IntersectsLine(b) {
+		return 0
+	}
+	return distSeriesSeries(a, b)
+}
+
+func distLinePoly(line *geometry.Line, poly *geometry.Poly) float64 {
+	if poly.Empty() {
+		return geoDistancePoints(line.Rect().Center(), poly.Rect().Center())
+	}
+	if !line.Empty() && poly.IntersectsLine(line) {
+		return 0
+	}
+	best := distSeriesSeries(line, poly.Exterior)
+	for _, hole := range poly.Holes {
+		best = math.Min(best, distSeriesSeries(line, hole))
+	}
+	return best
+}
+
+func distPolyPoly(a, b *geometry.Poly) float64 {
+	if a.Empty() || b.Empty() {
+		return geoDistancePoints(a.Rect().Center(), b.Rect().Center())
+	}
+	if a.IntersectsPoly(b) {
+		return 0
+	}
+	best := math.Inf(+1)
+	for _, ra := range polyRings(a) {
+		for _, rb := range polyRings(b) {
+			best = math.Min(best, distSeriesSeries(ra, rb))
+		}
+	}
+	return best
+}
+
+// distance returns the smallest distance from the non-empty children, or from
+// the collection center to the other center when all children are empty.
+func (g *collection) distance(
+	other geometry.Point, dist func(child Object) float64,
+) float64 {
+	best := math.Inf(+1)
+	for _, child := range g.Children() {
+		if child.Empty() {
+			continue
+		}
+		best = math.Min(best, dist(child))
+		if best == 0 {
+			break
+		}
+	}
+	if math.IsInf(best, +1) {
+		return geoDistancePoints(g.Center(), other)
+	}
+	return best
+}
@@ -0,0 +1,94 @@
+package geojson
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func expectMeters(t *testing.T, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", want, got)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// one degree of longitude along the equator
+	deg := geoDistancePoints(P(0, 0), P(1, 0))
+	poly := expectJSON(t,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`,
+		nil)
+
+	// points on the edge, inside, in the hole, and outside
+	expectMeters(t, poly.Distance(PO(5, 0)), 0)
+	expectMeters(t, poly.Distance(PO(1, 5)), 0)
+	expectMeters(t, PO(5, 0).Distance(poly), 0)
+	expectMeters(t, poly.Distance(PO(5, -1)), deg)
+	expectMeters(t, PO(5, -1).Distance(poly), deg)
+	expectMeters(t, poly.Distance(PO(11, 0)), deg)
+	expectMeters(t, poly.Distance(PO(5, 5)),
+		distPointSegment(P(5, 5), geometry.Segment{A: P(8, 2), B: P(8, 8)}))
+
+	// lines
+	expectMeters(t, poly.Distance(LO([]geometry.Point{P(11, -5), P(11, 0)})), deg)
+	expectMeters(t, poly.Distance(LO([]geometry.Point{P(-5, 1), P(15, 1)})), 0)
+	expectMeters(t, LO([]geometry.Point{P(-5, -1), P(15, -1)}).Distance(
+		LO([]geometry.Point{P(-1, -5), P(-1, 10)})), 0)
+	expectMeters(t, LO([]geometry.Point{P(0, 0), P(0, 10)}).Distance(
+		LO([]geometry.Point{P(-1, 0), P(-1, 10)})),
+		// meridians converge, so the top vertex is nearest
+		distPointSegment(P(0, 10), geometry.Segment{A: P(-1, 0), B: P(-1, 10)}))
+	expectMeters(t, LO([]geometry.Point{P(0, 0), P(0, 10)}).Distance(PO(-1, 0)), deg)
+
+	// polygons and rects
+	edge := distPointSegment(P(10, 10), geometry.Segment{A: P(11, 0), B: P(11, 10)})
+	expectMeters(t, poly.Distance(RO(11, 0, 12, 10)), edge)
+	expectMeters(t, RO(11, 0, 12, 10).Distance(poly), edge)
+	expectMeters(t, poly.Distance(RO(9, 0, 12, 10)), 0)
+	expectMeters(t, poly.Distance(RO(3, 3, 4, 4)),
+		distPointSegment(P(4, 3), geometry.Segment{A: P(2, 2), B: P(8, 2)}))
+	expectMeters(t, RO(-1, -1, 11, 11).Distance(poly), 0)
+	expectMeters(t, PPO([]geometry.Point{P(11, 0), P(12, 0), P(12, 10), P(11, 0)}, nil).
+		Distance(poly), deg)
+	expectMeters(t, RO(11, 0, 12, 10).Distance(PO(5, 0)),
+		geoDistancePoints(P(11, 0), P(5, 0)))
+
+	// collections
+	mp := expectJSON(t, `{"type":"MultiPoint","coordinates":[[20,0],[11,0]]}`, nil)
+	expectMeters(t, mp.Distance(poly), deg)
+	expectMeters(t, poly.Distance(mp), deg)
+	expectMeters(t, mp.Distance(PO(11, 0)), 0)
+	fc := expectJSON(t, `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[20,0]},"properties":{}},
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[-1,-5],[-1,0]]},"properties":{}}
+	]}`, nil)
+	expectMeters(t, fc.Distance(poly), deg)
+	expectMeters(t, poly.Distance(fc), deg)
+}
+
+func TestDistanceIndexed(t *testing.T) {
+	// a large indexed ring must agree with a brute force search
+	var points []geometry.Point
+	for i := 0; i < 1000; i++ {
+		a := float64(i) / 1000 * 2 * math.Pi
+		points = append(points, P(math.Cos(a)*10, math.Sin(a)*10))
+	}
+	points = append(points, points[0])
+	for _, kind := range []geometry.IndexKind{geometry.None,
+		geometry.RTree, geometry.QuadTree} {
+		opts := &ParseOptions{IndexGeometry: 64, IndexGeometryKind: kind}
+		line := NewLineString(geometry.NewLine(points,
+			&geometry.IndexOptions{Kind: opts.IndexGeometryKind, MinPoints: 64}))
+		for _, point := range []geometry.Point{P(0, 0), P(3, 4), P(12, -1),
+			P(-30, 40), P(10, 0)} {
+			want := math.Inf(+1)
+			for i := 0; i < len(points)-1; i++ {
+				want = math.Min(want, distPointSegment(point,
+					geometry.Segment{A: points[i], B: points[i+1]}))
+			}
+			expectMeters(t, line.Distance(PO(point.X, point.Y)), want)
+		}
+	}
+}
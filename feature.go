@@ -74,6 +74,26 @@ func (g *Feature) AppendJSON(dst []byte) []byte {
 
 }
 
+// AppendWKT appends the Well-Known Text of the feature's geometry to dst.
+func (g *Feature) AppendWKT(dst []byte) []byte {
+	return g.base.AppendWKT(dst)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *Feature) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *Feature) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *Feature) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *Feature) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -86,6 +106,21 @@ func (g *Feature) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *Feature) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *Feature) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binFeature)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*Feature)
+	return nil
+}
+
 func (g *Feature) Spatial() Spatial {
 	return g
 }
@@ -160,20 +195,102 @@ func parseJSONFeature(keys *parseKeys, opts *ParseOptions) (Object, error) {
 				// Circle
 				radius := gjson.Get(members, "properties.radius").Float()
 				units := gjson.Get(members, "properties.radius_units").String()
-				switch units {
-				case "", "m":
-				case "km":
-					radius *= 1000
-				default:
-					return nil, errCircleRadiusUnitsInvalid
+				if units == "" {
+					units = string(Meters)
+				}
+				circle, err := NewCircleWithUnits(point.base, radius,
+					Unit(units), opts.CircleOptions)
+				if err != nil {
+					return nil, err
 				}
-				return NewCircle(point.base, radius, 64), nil
+				return circle, nil
 			}
 		}
 	}
 	return &g, nil
 }
 
+// Centroid returns the centroid of the feature's geometry.
+func (g *Feature) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns a point on the feature's geometry.
+func (g *Feature) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the pole of the feature's geometry.
+func (g *Feature) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *Feature) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *Feature) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *Feature) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *Feature) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *Feature) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *Feature) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *Feature) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *Feature) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the feature.
+func (g *Feature) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the feature.
+func (g *Feature) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the feature.
+func (g *Feature) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the feature to a point.
+func (g *Feature) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the feature and obj.
+func (g *Feature) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *Feature) Distance(obj Object) float64 {
 	return g.base.Distance(obj)
 }
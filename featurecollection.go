@@ -18,11 +18,12 @@ func NewFeatureCollection(features []Object) *FeatureCollection {
 // AppendJSON appends the GeoJSON reprensentation to dst
 func (g *FeatureCollection) AppendJSON(dst []byte) []byte {
 	dst = append(dst, `{"type":"FeatureCollection","features":[`...)
-	for i := 0; i < len(g.children); i++ {
+	children := g.Children()
+	for i := 0; i < len(children); i++ {
 		if i > 0 {
 			dst = append(dst, ',')
 		}
-		dst = g.children[i].AppendJSON(dst)
+		dst = children[i].AppendJSON(dst)
 	}
 	dst = append(dst, ']')
 	if g.extra != nil {
@@ -45,6 +46,46 @@ func (g *FeatureCollection) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *FeatureCollection) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *FeatureCollection) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binFeatureCollection)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*FeatureCollection)
+	return nil
+}
+
+// Append adds features to the end of the collection, and to its index. The
+// methods that change a collection, which are Append, Remove, Replace, and
+// Filter, are safe for a single writer with concurrent readers. A slice that
+// was returned by Children doesn't change.
+func (g *FeatureCollection) Append(features ...Object) {
+	g.append(features)
+}
+
+// Remove removes the feature at an index and returns it.
+func (g *FeatureCollection) Remove(index int) Object {
+	return g.remove(index)
+}
+
+// Replace replaces the feature at an index and returns the old one.
+func (g *FeatureCollection) Replace(index int, feature Object) Object {
+	return g.replace(index, feature)
+}
+
+// Filter keeps the features that keep returns true for, and removes the
+// rest. It returns the number that were removed. The keep function must not
+// use the collection.
+func (g *FeatureCollection) Filter(keep func(feature Object) bool) int {
+	return g.filter(keep)
+}
+
 func parseJSONFeatureCollection(
 	keys *parseKeys, opts *ParseOptions,
 ) (Object, error) {
@@ -1,7 +1,11 @@
 package geojson
 
 import (
+	"math/rand"
+	"sync"
 	"testing"
+
+	"github.com/tidwall/geojson/geometry"
 )
 
 func TestFeatureCollection(t *testing.T) {
@@ -46,3 +50,160 @@ func TestForEach(t *testing.T) {
 		expect(t, objsA[i].String() == objsB[i].String())
 	}
 }
+
+func TestFeatureCollectionChange(t *testing.T) {
+	// count returns the number of children found by a search, and checks
+	// that every child is found by searching its own rectangle
+	count := func(g *FeatureCollection) int {
+		for _, child := range g.Children() {
+			var found bool
+			g.Search(child.Rect(), func(c Object) bool {
+				found = found || c == child
+				return !found
+			})
+			expect(t, found)
+		}
+		var n int
+		g.Search(g.Rect(), func(child Object) bool {
+			n++
+			return true
+		})
+		return n
+	}
+
+	fc := NewFeatureCollection(nil)
+	expect(t, fc.Empty() && !fc.Indexed())
+	for i := 0; i < 100; i++ {
+		fc.Append(PO(float64(i), float64(i)))
+	}
+	expect(t, fc.Indexed())
+	expect(t, len(fc.Children()) == 100)
+	expect(t, fc.Rect() == R(0, 0, 99, 99))
+	expect(t, count(fc) == 100)
+
+	children := fc.Children()
+	expect(t, fc.Remove(99) == children[99])
+	expect(t, fc.Remove(0) == children[0])
+	expect(t, len(children) == 100 && children[0] != fc.Children()[0])
+	expect(t, fc.Rect() == R(1, 1, 98, 98))
+	expect(t, count(fc) == 98)
+	expect(t, !fc.Intersects(PO(0, 0)))
+
+	old := fc.Replace(10, RO(-10, -10, -5, -5))
+	expect(t, old.Center() == P(11, 11))
+	expect(t, fc.Rect() == R(-10, -10, 98, 98))
+	expect(t, fc.Intersects(PO(-7, -7)))
+	expect(t, !fc.Intersects(PO(11, 11)))
+	expect(t, count(fc) == 98)
+
+	removed := fc.Filter(func(feature Object) bool {
+		return feature.Center().X < 50
+	})
+	expect(t, removed == 49)
+	expect(t, fc.Rect() == R(-10, -10, 49, 49))
+	expect(t, count(fc) == 49)
+	expect(t, fc.Filter(func(Object) bool { return true }) == 0)
+
+	// the slice passed to the constructor is not changed
+	points := []Object{PO(1, 1), PO(2, 2), PO(3, 3)}
+	fc = NewFeatureCollection(points[:2])
+	fc.Append(PO(4, 4))
+	expect(t, points[2].Center() == P(3, 3))
+	expectJSON(t, fc.JSON(), `{"type":"FeatureCollection","features":[{"type":"Point","coordinates":[1,1]},{"type":"Point","coordinates":[2,2]},{"type":"Point","coordinates":[4,4]}]}`)
+}
+
+func TestFeatureCollectionChangeConcurrent(t *testing.T) {
+	fc := NewFeatureCollection(nil)
+	for i := 0; i < 100; i++ {
+		fc.Append(PO(float64(i), 0))
+	}
+	var wg sync.WaitGroup
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				fc.Intersects(PO(50, 0))
+				fc.Nearby(P(50, 0), 5, 0, func(Object, float64) bool {
+					return true
+				})
+				fc.Rect()
+				for _, child := range fc.Children() {
+					child.Center()
+				}
+			}
+		}()
+	}
+	for i := 0; i < 1000; i++ {
+		fc.Append(PO(float64(i%100), 1))
+		fc.Replace(i%50, PO(float64(i%100), 2))
+		fc.Remove(len(fc.Children()) - 1)
+	}
+	close(done)
+	wg.Wait()
+	expect(t, len(fc.Children()) == 100)
+}
+
+// countingPoint counts the distances that are measured to it.
+type countingPoint struct {
+	*Point
+	count *int
+}
+
+func (p countingPoint) NearestPoint(point geometry.Point) Nearest {
+	*p.count++
+	return p.Point.NearestPoint(point)
+}
+
+func TestFeatureCollectionSearchFirst(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var measured int
+	children := make([]Object, 100000)
+	for i := range children {
+		children[i] = countingPoint{
+			PO(r.Float64()*360-180, r.Float64()*180-90), &measured,
+		}
+	}
+	fc := NewFeatureCollection(children)
+	expect(t, fc.Indexed())
+
+	// the search stops at the first child, rather than finding all of them
+	allocs := testing.AllocsPerRun(10, func() {
+		var count int
+		fc.Search(R(-180, -90, 180, 90), func(Object) bool {
+			count++
+			return false
+		})
+		expect(t, count == 1)
+	})
+	expect(t, allocs < 10)
+	expect(t, fc.Intersects(RO(-180, -90, 180, 90)))
+
+	// only the children near the point are measured
+	var count int
+	fc.Nearby(P(0, 0), 0, 0, func(Object, float64) bool {
+		count++
+		return false
+	})
+	expect(t, count == 1)
+	expect(t, measured < 1000)
+
+	// iter may change the collection, and sees it as it was
+	count = 0
+	fc.Search(R(-180, -90, 180, 90), func(child Object) bool {
+		count++
+		if count <= 10 {
+			fc.Append(PO(0, 0))
+			fc.Remove(0)
+		}
+		return true
+	})
+	expect(t, count == len(children))
+	expect(t, len(fc.Children()) == len(children))
+}
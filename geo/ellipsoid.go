@@ -0,0 +1,116 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import "math"
+
+// WGS84 ellipsoid
+const (
+	wgs84A = 6378137.0         // semi-major axis in meters
+	wgs84F = 1 / 298.257223563 // flattening
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+var (
+	wgs84E2 = wgs84F * (2 - wgs84F) // eccentricity squared
+	wgs84E  = math.Sqrt(wgs84E2)
+	wgs84QP = authalicQ(1)                  // q at the pole
+	wgs84RQ = wgs84A * math.Sqrt(wgs84QP/2) // authalic radius
+)
+
+// EllipsoidDistance returns the length in meters of the shortest path
+// between two points on the WGS84 ellipsoid, using Vincenty's formulae.
+// Nearly antipodal points, where the formulae may not converge, fall back to
+// DistanceTo.
+func EllipsoidDistance(latA, lonA, latB, lonB float64) (meters float64) {
+	// see https://www.movable-type.co.uk/scripts/latlong-vincenty.html
+	L := (lonB - lonA) * radians
+	tanU1 := (1 - wgs84F) * math.Tan(latA*radians)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	tanU2 := (1 - wgs84F) * math.Tan(latB*radians)
+	cosU2 := 1 / math.Sqrt(1+tanU2*tanU2)
+	sinU2 := tanU2 * cosU2
+
+	λ := L
+	var sinσ, cosσ, σ, cos2α, cos2σm float64
+	for i := 0; ; i++ {
+		if i == 200 {
+			return DistanceTo(latA, lonA, latB, lonB)
+		}
+		sinλ, cosλ := math.Sincos(λ)
+		x := cosU1*sinU2 - sinU1*cosU2*cosλ
+		sinσ = math.Sqrt(cosU2*sinλ*cosU2*sinλ + x*x)
+		if sinσ == 0 {
+			return 0 // coincident points
+		}
+		cosσ = sinU1*sinU2 + cosU1*cosU2*cosλ
+		σ = math.Atan2(sinσ, cosσ)
+		sinα := cosU1 * cosU2 * sinλ / sinσ
+		cos2α = 1 - sinα*sinα
+		cos2σm = 0 // equatorial line
+		if cos2α != 0 {
+			cos2σm = cosσ - 2*sinU1*sinU2/cos2α
+		}
+		C := wgs84F / 16 * cos2α * (4 + wgs84F*(4-3*cos2α))
+		λp := λ
+		λ = L + (1-C)*wgs84F*sinα*
+			(σ+C*sinσ*(cos2σm+C*cosσ*(-1+2*cos2σm*cos2σm)))
+		if math.Abs(λ-λp) <= 1e-12 {
+			break
+		}
+		if math.Abs(λ) > math.Pi {
+			return DistanceTo(latA, lonA, latB, lonB)
+		}
+	}
+	u2 := cos2α * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	Δσ := B * sinσ * (cos2σm + B/4*(cosσ*(-1+2*cos2σm*cos2σm)-
+		B/6*cos2σm*(-3+4*sinσ*sinσ)*(-3+4*cos2σm*cos2σm)))
+	return wgs84B * A * (σ - Δσ)
+}
+
+// authalicQ returns the q function for the sine of a geodetic latitude.
+func authalicQ(sinφ float64) float64 {
+	esinφ := wgs84E * sinφ
+	return (1 - wgs84E2) * (sinφ/(1-esinφ*esinφ) -
+		1/(2*wgs84E)*math.Log((1-esinφ)/(1+esinφ)))
+}
+
+// authalicLatitude converts a geodetic latitude in degrees to the latitude in
+// radians on the sphere with the same surface area as the WGS84 ellipsoid.
+func authalicLatitude(lat float64) float64 {
+	sinβ := authalicQ(math.Sin(lat*radians)) / wgs84QP
+	return math.Asin(math.Max(-1, math.Min(1, sinβ)))
+}
+
+// EllipsoidArea returns the area in square meters of the ring formed by
+// count points on the WGS84 ellipsoid, where point returns the coordinates of
+// the point at index. The latitudes are mapped to the equal-area authalic
+// sphere, on which the spherical excess of the ring is measured. The ring is
+// closed automatically and may be wound in either direction.
+func EllipsoidArea(count int, point func(index int) (lat, lon float64)) (
+	squareMeters float64,
+) {
+	if count < 3 {
+		return 0
+	}
+	// see Bevis & Cambareri, "Computing the area of a spherical polygon of
+	// arbitrary shape", and the geographiclib spherical polygon area.
+	var excess float64
+	lat, lon := point(count - 1)
+	t1 := math.Tan(authalicLatitude(lat) / 2)
+	λ1 := lon * radians
+	for i := 0; i < count; i++ {
+		lat, lon := point(i)
+		t2 := math.Tan(authalicLatitude(lat) / 2)
+		λ2 := lon * radians
+		Δλ := math.Remainder(λ2-λ1, 2*math.Pi)
+		excess += 2 * math.Atan2(math.Tan(Δλ/2)*(t1+t2), 1+t1*t2)
+		t1, λ1 = t2, λ2
+	}
+	return math.Abs(excess) * wgs84RQ * wgs84RQ
+}
@@ -48,6 +48,68 @@ func DistanceTo(latA, lonA, latB, lonB float64) (meters float64) {
 	return DistanceFromHaversine(a)
 }
 
+// segmentTrack returns the distance in meters along the great-circle segment
+// between points 'A' and 'B' to the point on the segment that is nearest to
+// another point, the length of the segment, and the distance from the point
+// to the nearest point.
+func segmentTrack(lat, lon, latA, lonA, latB, lonB float64) (
+	along, length, meters float64,
+) {
+	δ13 := DistanceTo(latA, lonA, lat, lon) / earthRadius
+	δ12 := DistanceTo(latA, lonA, latB, lonB) / earthRadius
+	if δ12 == 0 || δ13 == 0 {
+		return 0, δ12 * earthRadius, δ13 * earthRadius
+	}
+	// see https://www.movable-type.co.uk/scripts/latlong.html#cross-track
+	Δθ := (BearingTo(latA, lonA, lat, lon) - BearingTo(latA, lonA, latB, lonB)) *
+		radians
+	if math.Cos(Δθ) <= 0 {
+		// the point is behind 'A'
+		return 0, δ12 * earthRadius, δ13 * earthRadius
+	}
+	δxt := math.Asin(math.Sin(δ13) * math.Sin(Δθ))
+	cosδat := math.Cos(δ13) / math.Cos(δxt)
+	if cosδat > 1 {
+		cosδat = 1
+	} else if cosδat < -1 {
+		cosδat = -1
+	}
+	δat := math.Acos(cosδat)
+	if δat >= δ12 {
+		// the point is beyond 'B'
+		return δ12 * earthRadius, δ12 * earthRadius,
+			DistanceTo(latB, lonB, lat, lon)
+	}
+	return δat * earthRadius, δ12 * earthRadius, math.Abs(δxt) * earthRadius
+}
+
+// DistanceToSegment returns the distance in meters from a point to the
+// nearest point on the great-circle segment between points 'A' and 'B'.
+func DistanceToSegment(lat, lon, latA, lonA, latB, lonB float64) (
+	meters float64,
+) {
+	_, _, meters = segmentTrack(lat, lon, latA, lonA, latB, lonB)
+	return meters
+}
+
+// NearestPointOnSegment returns the point on the great-circle segment
+// between points 'A' and 'B' that is nearest to a point, and the distance in
+// meters between the two.
+func NearestPointOnSegment(lat, lon, latA, lonA, latB, lonB float64) (
+	nearLat, nearLon, meters float64,
+) {
+	along, length, meters := segmentTrack(lat, lon, latA, lonA, latB, lonB)
+	switch {
+	case along <= 0:
+		return latA, lonA, meters
+	case along >= length:
+		return latB, lonB, meters
+	}
+	nearLat, nearLon = DestinationPoint(latA, lonA, along,
+		BearingTo(latA, lonA, latB, lonB))
+	return nearLat, nearLon, meters
+}
+
 // DestinationPoint return the destination from a point based on a
 // distance and bearing.
 func DestinationPoint(lat, lon, meters, bearingDegrees float64) (
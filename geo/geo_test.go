@@ -274,3 +274,98 @@ func TestSemi(t *testing.T) {
 			avg*100, largest*100)
 	}
 }
+
+func TestDistanceToSegment(t *testing.T) {
+	// on the equator one degree of longitude is ~111195 meters
+	deg := DistanceTo(0, 0, 0, 1)
+	if d := DistanceToSegment(1, 0.5, 0, 0, 0, 1); !feq(d, deg) {
+		t.Fatalf("expected '%v', got '%v'", deg, d)
+	}
+	// behind 'A' and beyond 'B'
+	if d := DistanceToSegment(0, -1, 0, 0, 0, 1); !feq(d, deg) {
+		t.Fatalf("expected '%v', got '%v'", deg, d)
+	}
+	if d := DistanceToSegment(0, 3, 0, 0, 0, 1); !feq(d, deg*2) {
+		t.Fatalf("expected '%v', got '%v'", deg*2, d)
+	}
+	// on the segment and degenerate segments
+	if d := DistanceToSegment(0, 0.5, 0, 0, 0, 1); d > 1e-6 {
+		t.Fatalf("expected '%v', got '%v'", 0, d)
+	}
+	if d := DistanceToSegment(0, 1, 0, 0, 0, 0); !feq(d, deg) {
+		t.Fatalf("expected '%v', got '%v'", deg, d)
+	}
+	// never farther than the nearest endpoint
+	for i := 0; i < 10000; i++ {
+		lat, lon := rand.Float64()*160-80, rand.Float64()*360-180
+		latA, lonA := lat+rand.Float64()*2-1, lon+rand.Float64()*2-1
+		latB, lonB := lat+rand.Float64()*2-1, lon+rand.Float64()*2-1
+		d := DistanceToSegment(lat, lon, latA, lonA, latB, lonB)
+		if d > DistanceTo(lat, lon, latA, lonA)+1e-6 ||
+			d > DistanceTo(lat, lon, latB, lonB)+1e-6 {
+			t.Fatalf("distance '%v' is farther than an endpoint", d)
+		}
+	}
+}
+
+func TestNearestPointOnSegment(t *testing.T) {
+	lat, lon, meters := NearestPointOnSegment(1, 0.5, 0, 0, 0, 1)
+	if !feq(lat, 0) || !feq(lon, 0.5) || !feq(meters, DistanceTo(0, 0, 1, 0)) {
+		t.Fatalf("got '%v %v %v'", lat, lon, meters)
+	}
+	lat, lon, _ = NearestPointOnSegment(0, -1, 0, 0, 0, 1)
+	if lat != 0 || lon != 0 {
+		t.Fatalf("got '%v %v'", lat, lon)
+	}
+	lat, lon, _ = NearestPointOnSegment(0, 3, 0, 0, 0, 1)
+	if lat != 0 || lon != 1 {
+		t.Fatalf("got '%v %v'", lat, lon)
+	}
+	// the nearest point is as far away as the segment distance
+	for i := 0; i < 10000; i++ {
+		lat, lon := rand.Float64()*160-80, rand.Float64()*360-180
+		latA, lonA := lat+rand.Float64()*2-1, lon+rand.Float64()*2-1
+		latB, lonB := lat+rand.Float64()*2-1, lon+rand.Float64()*2-1
+		nlat, nlon, meters := NearestPointOnSegment(lat, lon,
+			latA, lonA, latB, lonB)
+		if math.Abs(DistanceTo(lat, lon, nlat, nlon)-meters) > 0.001 {
+			t.Fatalf("expected '%v', got '%v'", meters,
+				DistanceTo(lat, lon, nlat, nlon))
+		}
+	}
+}
+
+func TestEllipsoid(t *testing.T) {
+	// one degree along the equator and a meridian on WGS84
+	if d := EllipsoidDistance(0, 0, 0, 1); math.Abs(d-111319.491) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", 111319.491, d)
+	}
+	if d := EllipsoidDistance(0, 0, 1, 0); math.Abs(d-110574.389) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", 110574.389, d)
+	}
+	if d := EllipsoidDistance(10, 20, 10, 20); d != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, d)
+	}
+	// nearly antipodal points fall back to the sphere
+	if d := EllipsoidDistance(0, 0, 0.5, 179.7); math.IsNaN(d) || d < 19e6 {
+		t.Fatalf("invalid antipodal distance '%v'", d)
+	}
+	// a one degree cell on the equator is about 12308.8 square km
+	cell := [][2]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}
+	area := EllipsoidArea(len(cell), func(i int) (float64, float64) {
+		return cell[i][0], cell[i][1]
+	})
+	if math.Abs(area-12308778361) > 1e6 {
+		t.Fatalf("expected '%v', got '%v'", 12308778361, area)
+	}
+	// winding does not matter
+	area2 := EllipsoidArea(len(cell), func(i int) (float64, float64) {
+		return cell[len(cell)-1-i][0], cell[len(cell)-1-i][1]
+	})
+	if !feq(area, area2) {
+		t.Fatalf("expected '%v', got '%v'", area, area2)
+	}
+	if area := EllipsoidArea(2, nil); area != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, area)
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errBinaryInvalid = errors.New("invalid binary data")
+
+// series flags
+const (
+	binClosed    = 1 << 0
+	binClockwise = 1 << 1
+	binConvex    = 1 << 2
+)
+
+func appendBinaryUint32(dst []byte, n uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], n)
+	return append(dst, b[:]...)
+}
+
+func appendBinaryPoint(dst []byte, point Point) []byte {
+	dst = appendFloat(dst, point.X)
+	return appendFloat(dst, point.Y)
+}
+
+// appendBinarySeries appends the series points, the precalculated convex,
+// clockwise and rect values, and the compressed segment index.
+func appendBinarySeries(dst []byte, series Series) []byte {
+	bs, ok := series.(*baseSeries)
+	if !ok {
+		// convert to a baseSeries, such as for a Rect exterior.
+		nbs := makeSeries(seriesCopyPoints(series), false, true, &IndexOptions{})
+		bs = &nbs
+	}
+	var flags byte
+	if bs.closed {
+		flags |= binClosed
+	}
+	if bs.clockwise {
+		flags |= binClockwise
+	}
+	if bs.convex {
+		flags |= binConvex
+	}
+	dst = append(dst, flags, byte(bs.indexKind))
+	dst = appendBinaryPoint(dst, bs.rect.Min)
+	dst = appendBinaryPoint(dst, bs.rect.Max)
+	dst = appendBinaryUint32(dst, uint32(len(bs.points)))
+	for _, point := range bs.points {
+		dst = appendBinaryPoint(dst, point)
+	}
+	if index, ok := bs.index.([]byte); ok {
+		dst = appendBinaryUint32(dst, uint32(len(index)))
+		dst = append(dst, index...)
+	} else {
+		dst = appendBinaryUint32(dst, 0)
+	}
+	return dst
+}
+
+// binReader is a cursor over binary data.
+type binReader struct {
+	data []byte
+	i    int
+	err  error
+}
+
+func (r *binReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data)-r.i {
+		r.err = errBinaryInvalid
+		return nil
+	}
+	b := r.data[r.i : r.i+n]
+	r.i += n
+	return b
+}
+
+func (r *binReader) byte() byte {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *binReader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *binReader) float() float64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+func (r *binReader) point() Point {
+	return Point{X: r.float(), Y: r.float()}
+}
+
+// count reads an element count and checks that there's enough data remaining
+// for each element to be at least minSize bytes.
+func (r *binReader) count(minSize int) int {
+	n := int(r.uint32())
+	if r.err == nil && n*minSize > len(r.data)-r.i {
+		r.err = errBinaryInvalid
+		return 0
+	}
+	return n
+}
+
+func (r *binReader) series() *baseSeries {
+	var series baseSeries
+	flags := r.byte()
+	series.closed = flags&binClosed != 0
+	series.clockwise = flags&binClockwise != 0
+	series.convex = flags&binConvex != 0
+	series.indexKind = IndexKind(r.byte())
+	series.rect.Min = r.point()
+	series.rect.Max = r.point()
+	n := r.count(16)
+	if r.err != nil {
+		return nil
+	}
+	series.points = make([]Point, n)
+	for i := 0; i < n; i++ {
+		series.points[i] = r.point()
+	}
+	index := r.bytes(int(r.uint32()))
+	if r.err != nil {
+		return nil
+	}
+	// the precalculated values must be the ones for the points
+	convex, rect, clockwise := processPoints(series.points, series.closed)
+	if convex != series.convex || rect != series.rect ||
+		clockwise != series.clockwise || series.indexKind > QuadTree {
+		r.err = errBinaryInvalid
+		return nil
+	}
+	if len(index) > 0 {
+		// the index must be a compressed rtree or qtree of the series, and
+		// must know its own length.
+		if len(index) < 5 || index[0] != byte(series.indexKind) ||
+			int(binary.LittleEndian.Uint32(index[1:])) != len(index) ||
+			!validIndex(&series, index) {
+			r.err = errBinaryInvalid
+			return nil
+		}
+		series.index = append([]byte(nil), index...)
+	}
+	return &series
+}
+
+// indexChecker checks a compressed index, which must have each segment of
+// its series once, in nodes that are laid out the way that they're written.
+type indexChecker struct {
+	data   []byte
+	series *baseSeries
+	seen   []bool
+}
+
+// validIndex returns true if a compressed index can be searched, and finds
+// the segments of the series.
+func validIndex(series *baseSeries, index []byte) bool {
+	c := &indexChecker{
+		data:   index,
+		series: series,
+		seen:   make([]bool, series.NumSegments()),
+	}
+	end := -1
+	switch {
+	case index[0] == byte(RTree) && len(index) == 5:
+		// no segments
+		end = 5
+	case index[0] == byte(RTree):
+		end = c.rnode(6, int(index[5]), series.rect)
+	case index[0] == byte(QuadTree):
+		end = c.qnode(5, 0, series.rect)
+	}
+	if end != len(index) {
+		return false
+	}
+	for _, seen := range c.seen {
+		if !seen {
+			return false
+		}
+	}
+	return true
+}
+
+// has returns true if there are n bytes at addr.
+func (c *indexChecker) has(addr, n int) bool {
+	return addr >= 0 && n >= 0 && n <= len(c.data)-addr
+}
+
+// item checks a segment in a node, which must be in the node's rect.
+func (c *indexChecker) item(addr int, ibytes byte, rect Rect) bool {
+	item := int(readNum(c.data[addr:], ibytes))
+	if item >= len(c.seen) || c.seen[item] ||
+		!rect.ContainsRect(c.series.SegmentAt(item).Rect()) {
+		return false
+	}
+	c.seen[item] = true
+	return true
+}
+
+// rnode checks an rtree node, and returns the address after it, or -1.
+func (c *indexChecker) rnode(addr, height int, parent Rect) int {
+	if !c.has(addr, 33) {
+		return -1
+	}
+	var rect Rect
+	rect.Min.X = math.Float64frombits(binary.LittleEndian.Uint64(c.data[addr:]))
+	rect.Min.Y = math.Float64frombits(binary.LittleEndian.Uint64(c.data[addr+8:]))
+	rect.Max.X = math.Float64frombits(binary.LittleEndian.Uint64(c.data[addr+16:]))
+	rect.Max.Y = math.Float64frombits(binary.LittleEndian.Uint64(c.data[addr+24:]))
+	count := int(c.data[addr+32])
+	addr += 33
+	if !parent.ContainsRect(rect) {
+		return -1
+	}
+	if height == 0 {
+		if !c.has(addr, 1) {
+			return -1
+		}
+		ibytes := c.data[addr]
+		addr++
+		if (ibytes != 1 && ibytes != 2 && ibytes != 4) ||
+			!c.has(addr, count*int(ibytes)) {
+			return -1
+		}
+		for i := 0; i < count; i++ {
+			if !c.item(addr, ibytes, rect) {
+				return -1
+			}
+			addr += int(ibytes)
+		}
+		return addr
+	}
+	if !c.has(addr, count*4) {
+		return -1
+	}
+	next := addr + count*4
+	for i := 0; i < count; i++ {
+		// each child comes right after the one before it
+		if int(binary.LittleEndian.Uint32(c.data[addr+i*4:])) != next {
+			return -1
+		}
+		next = c.rnode(next, height-1, rect)
+		if next == -1 {
+			return -1
+		}
+	}
+	return next
+}
+
+// qnode checks a qtree node, and returns the address after it, or -1.
+func (c *indexChecker) qnode(addr, depth int, bounds Rect) int {
+	if depth > qMaxDepth || !c.has(addr, 1) {
+		return -1
+	}
+	ibytes := c.data[addr]
+	addr++
+	if (ibytes != 1 && ibytes != 2 && ibytes != 4) || !c.has(addr, int(ibytes)) {
+		return -1
+	}
+	count := int(readNum(c.data[addr:], ibytes))
+	addr += int(ibytes)
+	if !c.has(addr, count*int(ibytes)+1) {
+		return -1
+	}
+	for i := 0; i < count; i++ {
+		if !c.item(addr, ibytes, bounds) {
+			return -1
+		}
+		addr += int(ibytes)
+	}
+	split := c.data[addr]
+	addr++
+	if split == 0 {
+		return addr
+	}
+	if split != 1 {
+		return -1
+	}
+	var marks [4]int
+	for q := 0; q < 4; q++ {
+		if !c.has(addr, 1) {
+			return -1
+		}
+		use := c.data[addr]
+		addr++
+		switch use {
+		case 0:
+			marks[q] = -1
+		case 1:
+			if !c.has(addr, 4) {
+				return -1
+			}
+			marks[q] = addr
+			addr += 4
+		default:
+			return -1
+		}
+	}
+	for q := 0; q < 4; q++ {
+		if marks[q] == -1 {
+			continue
+		}
+		// each quad comes right after the one before it
+		if int(binary.LittleEndian.Uint32(c.data[marks[q]:])) != addr {
+			return -1
+		}
+		addr = c.qnode(addr, depth+1, quadBounds(bounds, q))
+		if addr == -1 {
+			return -1
+		}
+	}
+	return addr
+}
+
+// AppendBinary appends the binary representation of the line to dst. This
+// includes the prebuilt segment index.
+func (line *Line) AppendBinary(dst []byte) []byte {
+	return appendBinarySeries(dst, &line.baseSeries)
+}
+
+// MarshalBinary returns the binary representation of the line, including the
+// prebuilt segment index.
+func (line *Line) MarshalBinary() ([]byte, error) {
+	return line.AppendBinary(nil), nil
+}
+
+// UnmarshalBinary loads the line from data that was created by MarshalBinary.
+// The segment index is checked against the points, and is restored as-is
+// rather than rebuilt.
+func (line *Line) UnmarshalBinary(data []byte) error {
+	r := &binReader{data: data}
+	series := r.series()
+	if r.err == nil && r.i != len(r.data) {
+		r.err = errBinaryInvalid
+	}
+	if r.err != nil {
+		return r.err
+	}
+	line.baseSeries = *series
+	return nil
+}
+
+// AppendBinary appends the binary representation of the polygon to dst. This
+// includes the prebuilt segment indexes for the exterior and holes.
+func (poly *Poly) AppendBinary(dst []byte) []byte {
+	if poly.Exterior == nil {
+		return appendBinaryUint32(dst, 0)
+	}
+	dst = appendBinaryUint32(dst, uint32(1+len(poly.Holes)))
+	dst = appendBinarySeries(dst, poly.Exterior)
+	for _, hole := range poly.Holes {
+		dst = appendBinarySeries(dst, hole)
+	}
+	return dst
+}
+
+// MarshalBinary returns the binary representation of the polygon, including
+// the prebuilt segment indexes.
+func (poly *Poly) MarshalBinary() ([]byte, error) {
+	return poly.AppendBinary(nil), nil
+}
+
+// UnmarshalBinary loads the polygon from data that was created by
+// MarshalBinary. The segment indexes are checked against the points, and are
+// restored as-is rather than rebuilt.
+func (poly *Poly) UnmarshalBinary(data []byte) error {
+	r := &binReader{data: data}
+	n := r.count(1)
+	var npoly Poly
+	for i := 0; i < n && r.err == nil; i++ {
+		series := r.series()
+		if r.err != nil {
+			break
+		}
+		if !series.closed {
+			r.err = errBinaryInvalid
+			break
+		}
+		if i == 0 {
+			npoly.Exterior = series
+		} else {
+			npoly.Holes = append(npoly.Holes, series)
+		}
+	}
+	if r.err == nil && r.i != len(r.data) {
+		r.err = errBinaryInvalid
+	}
+	if r.err != nil {
+		return r.err
+	}
+	*poly = npoly
+	return nil
+}
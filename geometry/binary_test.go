@@ -0,0 +1,118 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLineBinary(t *testing.T) {
+	for _, kind := range []IndexKind{None, RTree, QuadTree} {
+		line := NewLine(tx, &IndexOptions{Kind: kind, MinPoints: 1})
+		data, err := line.MarshalBinary()
+		expect(t, err == nil)
+		var line2 Line
+		expect(t, line2.UnmarshalBinary(data) == nil)
+		expect(t, line2.NumPoints() == line.NumPoints())
+		expect(t, line2.Rect() == line.Rect())
+		expect(t, line2.Convex() == line.Convex())
+		expect(t, line2.Clockwise() == line.Clockwise())
+		if kind == None {
+			expect(t, line2.Index() == nil)
+		} else {
+			expect(t, bytes.Equal(line2.Index().([]byte), line.Index().([]byte)))
+		}
+		expect(t, line2.IntersectsPoint(tx[10]))
+		data2, _ := line2.MarshalBinary()
+		expect(t, bytes.Equal(data, data2))
+		// truncated data
+		for i := 0; i < len(data)-1; i += 97 {
+			expect(t, line2.UnmarshalBinary(data[:i]) != nil)
+		}
+		expect(t, line2.UnmarshalBinary(append(data, 0)) != nil)
+	}
+}
+
+func TestPolyBinary(t *testing.T) {
+	for _, kind := range []IndexKind{None, RTree, QuadTree} {
+		opts := &IndexOptions{Kind: kind, MinPoints: 1}
+		small := []Point{{-100, 30}, {-99, 30}, {-99, 31}, {-100, 31}, {-100, 30}}
+		poly := NewPoly(tx, [][]Point{small}, opts)
+		data, err := poly.MarshalBinary()
+		expect(t, err == nil)
+		var poly2 Poly
+		expect(t, poly2.UnmarshalBinary(data) == nil)
+		expect(t, len(poly2.Holes) == 1)
+		expect(t, poly2.Rect() == poly.Rect())
+		expect(t, poly2.Exterior.NumPoints() == len(tx))
+		if kind != None {
+			expect(t, bytes.Equal(poly2.Exterior.Index().([]byte),
+				poly.Exterior.Index().([]byte)))
+		}
+		expect(t, poly2.ContainsPoint(P(-97.7, 30.3)))
+		expect(t, !poly2.ContainsPoint(P(-99.5, 30.5)))
+		expect(t, !poly2.ContainsPoint(P(-80, 30.3)))
+		expect(t, poly2.UnmarshalBinary(data[:len(data)-1]) != nil)
+	}
+	// rect exteriors are stored as regular rings
+	poly := &Poly{Exterior: R(0, 0, 10, 10)}
+	data, _ := poly.MarshalBinary()
+	var poly2 Poly
+	expect(t, poly2.UnmarshalBinary(data) == nil)
+	expect(t, poly2.Rect() == R(0, 0, 10, 10))
+	expect(t, poly2.ContainsPoint(P(5, 5)))
+	// empty
+	data, _ = (&Poly{}).MarshalBinary()
+	expect(t, poly2.UnmarshalBinary(data) == nil)
+	expect(t, poly2.Empty())
+	// lines are not rings
+	data, _ = NewLine(u1, nil).MarshalBinary()
+	data = append([]byte{1, 0, 0, 0}, data...)
+	expect(t, poly2.UnmarshalBinary(data) != nil)
+}
+
+func TestPolyBinaryForgedIndex(t *testing.T) {
+	for _, kind := range []IndexKind{RTree, QuadTree} {
+		opts := &IndexOptions{Kind: kind, MinPoints: 1}
+		var points []Point
+		for i := 0; i < 200; i++ {
+			points = append(points, P(float64(i), float64(i%2)))
+		}
+		points = append(points, P(100, 50), points[0])
+		big := NewPoly(points, nil, opts)
+		small := NewPoly(tx[:10], nil, opts)
+
+		// the index of the big polygon on the points of the small one
+		forged := *small.Exterior.(*baseSeries)
+		forged.index = big.Exterior.(*baseSeries).index
+		data := appendBinaryUint32(nil, 1)
+		data = appendBinarySeries(data, &forged)
+		var poly Poly
+		expect(t, poly.UnmarshalBinary(data) == errBinaryInvalid)
+
+		// wrong precalculated values
+		forged = *small.Exterior.(*baseSeries)
+		forged.rect.Max.X++
+		data = appendBinarySeries(appendBinaryUint32(nil, 1), &forged)
+		expect(t, poly.UnmarshalBinary(data) == errBinaryInvalid)
+
+		// every byte of the index changed, which either fails or is still
+		// safe to search
+		data, _ = big.MarshalBinary()
+		index := big.Exterior.(*baseSeries).index.([]byte)
+		start := len(data) - len(index)
+		for i := start; i < len(data); i++ {
+			for _, b := range []byte{0, 1, 0xFF, data[i] ^ 0x10} {
+				bad := append([]byte(nil), data...)
+				bad[i] = b
+				if poly.UnmarshalBinary(bad) == nil {
+					poly.ContainsPoint(P(100, 25))
+					poly.IntersectsRect(R(-10, -10, 300, 300))
+				}
+			}
+		}
+	}
+}
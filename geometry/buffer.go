@@ -0,0 +1,396 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import "math"
+
+// CapStyle is the shape at the ends of a buffered line.
+type CapStyle byte
+
+// CapStyle types
+const (
+	CapRound  CapStyle = iota // a half circle
+	CapFlat                   // nothing beyond the ends
+	CapSquare                 // a half square
+)
+
+// JoinStyle is the shape at the outer corners of a buffered line or polygon.
+type JoinStyle byte
+
+// JoinStyle types
+const (
+	JoinRound JoinStyle = iota // an arc
+	JoinMitre                  // a sharp corner, up to the mitre limit
+	JoinBevel                  // a straight cut across the corner
+)
+
+// BufferOptions are buffering options
+type BufferOptions struct {
+	// QuadrantSegments is the number of segments used for a quarter circle.
+	QuadrantSegments int
+	CapStyle         CapStyle
+	JoinStyle        JoinStyle
+	// MitreLimit is the longest distance of a mitre join from its corner,
+	// as a multiple of the buffer distance. Sharper corners are beveled.
+	MitreLimit float64
+}
+
+// DefaultBufferOptions uses round caps and joins.
+var DefaultBufferOptions = &BufferOptions{
+	QuadrantSegments: 8,
+	CapStyle:         CapRound,
+	JoinStyle:        JoinRound,
+	MitreLimit:       5,
+}
+
+// bufferArc returns the points on an arc around a center, starting at an
+// angle and turning by sweep radians, which is counter-clockwise when
+// positive. The first and last points are start and end, which are already
+// on the arc, so that they exactly match the neighboring shapes.
+func bufferArc(center Point, distance, angle, sweep float64, start, end Point,
+	opts *BufferOptions,
+) []Point {
+	step := math.Pi / 2 / float64(opts.QuadrantSegments)
+	n := int(math.Ceil(math.Abs(sweep)/step - 1e-9))
+	points := []Point{start}
+	for i := 1; i < n; i++ {
+		a := angle + sweep*float64(i)/float64(n)
+		points = append(points, Point{
+			X: center.X + distance*math.Cos(a),
+			Y: center.Y + distance*math.Sin(a),
+		})
+	}
+	return append(points, end)
+}
+
+// bufferShape appends a closed shape to the buffer pieces. Shapes without
+// area are skipped.
+func bufferShape(pieces [][]*Poly, points []Point) [][]*Poly {
+	points = append(points, points[0])
+	poly := NewPoly(points, nil, nil)
+	if seriesArea(poly.Exterior) == 0 {
+		return pieces
+	}
+	return append(pieces, []*Poly{poly})
+}
+
+// bufferOffset returns a point moved by the distance along the left normal of
+// a unit direction, or the right normal when the distance is negative.
+func bufferOffset(point Point, dx, dy, distance float64) Point {
+	return Point{X: point.X - dy*distance, Y: point.Y + dx*distance}
+}
+
+// bufferDirection returns the unit direction from a to b, which must be
+// different points.
+func bufferDirection(a, b Point) (dx, dy float64) {
+	dx, dy = b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	return dx / length, dy / length
+}
+
+// bufferPoint returns the pieces for the area around a single point.
+func bufferPoint(pieces [][]*Poly, point Point, distance float64,
+	opts *BufferOptions,
+) [][]*Poly {
+	switch opts.CapStyle {
+	case CapFlat:
+		return pieces
+	case CapSquare:
+		return bufferShape(pieces, []Point{
+			{X: point.X - distance, Y: point.Y - distance},
+			{X: point.X + distance, Y: point.Y - distance},
+			{X: point.X + distance, Y: point.Y + distance},
+			{X: point.X - distance, Y: point.Y + distance},
+		})
+	}
+	start := Point{X: point.X + distance, Y: point.Y}
+	arc := bufferArc(point, distance, 0, 2*math.Pi, start, start, opts)
+	return bufferShape(pieces, arc[:len(arc)-1])
+}
+
+// bufferCap returns the pieces for the end of a line, where the unit
+// direction points away from the line.
+func bufferCap(pieces [][]*Poly, end Point, dx, dy, distance float64,
+	opts *BufferOptions,
+) [][]*Poly {
+	left := bufferOffset(end, dx, dy, distance)
+	right := bufferOffset(end, dx, dy, -distance)
+	switch opts.CapStyle {
+	case CapRound:
+		arc := bufferArc(end, distance, math.Atan2(dx, -dy), -math.Pi,
+			left, right, opts)
+		return bufferShape(pieces, append(arc, end))
+	case CapSquare:
+		return bufferShape(pieces, []Point{
+			left,
+			{X: left.X + dx*distance, Y: left.Y + dy*distance},
+			{X: right.X + dx*distance, Y: right.Y + dy*distance},
+			right,
+			end,
+		})
+	}
+	return pieces
+}
+
+// bufferJoin returns the pieces for the outer side of a corner, where the
+// unit directions are of the segments before and after the corner.
+func bufferJoin(pieces [][]*Poly, corner Point, dx1, dy1, dx2, dy2,
+	distance float64, opts *BufferOptions,
+) [][]*Poly {
+	cross := dx1*dy2 - dy1*dx2
+	dot := dx1*dx2 + dy1*dy2
+	if cross == 0 && dot > 0 {
+		// straight
+		return pieces
+	}
+	// the outer side is on the right for left turns
+	side := distance
+	if cross > 0 {
+		side = -distance
+	}
+	p1 := bufferOffset(corner, dx1, dy1, side)
+	p2 := bufferOffset(corner, dx2, dy2, side)
+	switch opts.JoinStyle {
+	case JoinRound:
+		angle := math.Atan2(p1.Y-corner.Y, p1.X-corner.X)
+		sweep := math.Atan2(cross, dot)
+		if cross == 0 {
+			// turning back, so the arc goes around the front of the corner
+			sweep = -math.Pi
+		}
+		arc := bufferArc(corner, distance, angle, sweep, p1, p2, opts)
+		return bufferShape(pieces, append([]Point{corner}, arc...))
+	case JoinMitre:
+		if ratio := math.Sqrt(2 / (1 + dot)); dot > -1 &&
+			ratio <= opts.MitreLimit {
+			// the outer normals meet at the mitre point
+			nx := (p1.X - corner.X + p2.X - corner.X) / (1 + dot)
+			ny := (p1.Y - corner.Y + p2.Y - corner.Y) / (1 + dot)
+			return bufferShape(pieces, []Point{
+				corner, p1, {X: corner.X + nx, Y: corner.Y + ny}, p2,
+			})
+		}
+	}
+	return bufferShape(pieces, []Point{corner, p1, p2})
+}
+
+// bufferSeries returns the pieces for the area around the segments of a
+// series. A closed series has joins at every point, and no caps.
+func bufferSeries(pieces [][]*Poly, points []Point, closed bool,
+	distance float64, opts *BufferOptions,
+) [][]*Poly {
+	// remove repeated points
+	var pts []Point
+	for i, point := range points {
+		if i == 0 || point != pts[len(pts)-1] {
+			pts = append(pts, point)
+		}
+	}
+	if closed && len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+		pts = pts[:len(pts)-1]
+	}
+	if len(pts) == 1 {
+		return bufferPoint(pieces, pts[0], distance, opts)
+	}
+	n := len(pts) - 1
+	if closed {
+		n = len(pts)
+	}
+	for i := 0; i < n; i++ {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		dx, dy := bufferDirection(a, b)
+		// the line points are included, so that the joins and caps share
+		// exactly the same edges
+		pieces = bufferShape(pieces, []Point{
+			bufferOffset(a, dx, dy, -distance),
+			bufferOffset(b, dx, dy, -distance),
+			b,
+			bufferOffset(b, dx, dy, distance),
+			bufferOffset(a, dx, dy, distance),
+			a,
+		})
+		if i < n-1 || closed {
+			c := pts[(i+2)%len(pts)]
+			dx2, dy2 := bufferDirection(b, c)
+			pieces = bufferJoin(pieces, b, dx, dy, dx2, dy2, distance, opts)
+		}
+	}
+	if !closed {
+		dx, dy := bufferDirection(pts[1], pts[0])
+		pieces = bufferCap(pieces, pts[0], dx, dy, distance, opts)
+		dx, dy = bufferDirection(pts[n-1], pts[n])
+		pieces = bufferCap(pieces, pts[n], dx, dy, distance, opts)
+	}
+	return pieces
+}
+
+// bufferQuantum returns the size of the grid that buffered coordinates are
+// rounded to, which is a power of two that is about a billionth of the
+// largest coordinate. Points that are meant to be the same, but differ by
+// floating point errors, become exactly the same, which the overlay requires.
+func bufferQuantum(rect Rect, distance float64) float64 {
+	scale := math.Max(
+		math.Max(math.Abs(rect.Min.X), math.Abs(rect.Max.X)),
+		math.Max(math.Abs(rect.Min.Y), math.Abs(rect.Max.Y)),
+	) + math.Abs(distance)
+	_, exp := math.Frexp(scale)
+	return math.Ldexp(1, exp-30)
+}
+
+// bufferSnapRing returns the points of a ring rounded to the quantum, or nil
+// when the rounded ring has no area.
+func bufferSnapRing(ring Ring, quantum float64) []Point {
+	var points []Point
+	for _, point := range ringPoints(ring) {
+		point = Point{
+			X: math.Round(point.X/quantum) * quantum,
+			Y: math.Round(point.Y/quantum) * quantum,
+		}
+		if len(points) == 0 || point != points[len(points)-1] {
+			points = append(points, point)
+		}
+	}
+	if len(points) < 4 || seriesArea(newRing(points, nil)) == 0 {
+		return nil
+	}
+	return points
+}
+
+// bufferSnap returns the polygons rounded to the quantum.
+func bufferSnap(polys []*Poly, quantum float64) []*Poly {
+	var snapped []*Poly
+	for _, poly := range polys {
+		exterior := bufferSnapRing(poly.Exterior, quantum)
+		if exterior == nil {
+			continue
+		}
+		var holes [][]Point
+		for _, hole := range poly.Holes {
+			if hole := bufferSnapRing(hole, quantum); hole != nil {
+				holes = append(holes, hole)
+			}
+		}
+		snapped = append(snapped, NewPoly(exterior, holes, DefaultIndexOptions))
+	}
+	return snapped
+}
+
+// UnionPolys returns the union of many polygons, which may overlap each
+// other. The polygons are merged in pairs, then the pairs are merged, and so
+// on, which is much faster than merging them one at a time. The coordinates
+// are rounded to about a billionth of the largest coordinate.
+func UnionPolys(polys []*Poly) []*Poly {
+	polys = nonEmptyPolys(polys)
+	if len(polys) == 0 {
+		return nil
+	}
+	rect := polys[0].Rect()
+	groups := make([][]*Poly, len(polys))
+	for i, poly := range polys {
+		other := poly.Rect()
+		rect.Min.X = math.Min(rect.Min.X, other.Min.X)
+		rect.Min.Y = math.Min(rect.Min.Y, other.Min.Y)
+		rect.Max.X = math.Max(rect.Max.X, other.Max.X)
+		rect.Max.Y = math.Max(rect.Max.Y, other.Max.Y)
+		groups[i] = []*Poly{poly}
+	}
+	return unionGroups(groups, bufferQuantum(rect, 0))
+}
+
+// unionGroups returns the union of groups of polygons, where the polygons in
+// each group do not overlap each other. Every group and every partial union
+// is rounded to the quantum.
+func unionGroups(groups [][]*Poly, quantum float64) []*Poly {
+	var snapped [][]*Poly
+	for _, group := range groups {
+		if group = bufferSnap(group, quantum); len(group) > 0 {
+			snapped = append(snapped, group)
+		}
+	}
+	groups = snapped
+	for len(groups) > 1 {
+		var merged [][]*Poly
+		for i := 0; i < len(groups); i += 2 {
+			if i+1 == len(groups) {
+				merged = append(merged, groups[i])
+			} else {
+				merged = append(merged, bufferSnap(
+					overlay(groups[i], groups[i+1], OverlayUnion, quantum),
+					quantum))
+			}
+		}
+		groups = merged
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups[0]
+}
+
+func bufferOptions(opts *BufferOptions) *BufferOptions {
+	if opts == nil {
+		return DefaultBufferOptions
+	}
+	if opts.QuadrantSegments < 1 || opts.MitreLimit <= 0 {
+		copied := *opts
+		if copied.QuadrantSegments < 1 {
+			copied.QuadrantSegments = DefaultBufferOptions.QuadrantSegments
+		}
+		if copied.MitreLimit <= 0 {
+			copied.MitreLimit = DefaultBufferOptions.MitreLimit
+		}
+		opts = &copied
+	}
+	return opts
+}
+
+// Buffer returns the area within a distance of the point, which is a circle
+// for round caps, a square for square caps, and nothing for flat caps. A nil
+// opts uses the DefaultBufferOptions.
+func (point Point) Buffer(distance float64, opts *BufferOptions) []*Poly {
+	if distance <= 0 {
+		return nil
+	}
+	quantum := bufferQuantum(point.Rect(), distance)
+	return unionGroups(bufferPoint(nil, point, distance, bufferOptions(opts)),
+		quantum)
+}
+
+// Buffer returns the area within a distance of the line. A nil opts uses the
+// DefaultBufferOptions.
+func (line *Line) Buffer(distance float64, opts *BufferOptions) []*Poly {
+	if line == nil || line.NumPoints() == 0 || distance <= 0 {
+		return nil
+	}
+	quantum := bufferQuantum(line.Rect(), distance)
+	return unionGroups(bufferSeries(nil, seriesPoints(line), false, distance,
+		bufferOptions(opts)), quantum)
+}
+
+// Buffer returns the polygon grown by a distance, or shrunk when the distance
+// is negative. The cap style is not used. A nil opts uses the
+// DefaultBufferOptions.
+func (poly *Poly) Buffer(distance float64, opts *BufferOptions) []*Poly {
+	if poly == nil || poly.Empty() {
+		return nil
+	}
+	if distance == 0 {
+		return []*Poly{poly}
+	}
+	opts = bufferOptions(opts)
+	var pieces [][]*Poly
+	for _, ring := range polyRings(poly) {
+		pieces = bufferSeries(pieces, ringPoints(ring), true,
+			math.Abs(distance), opts)
+	}
+	quantum := bufferQuantum(poly.Rect(), distance)
+	edges := unionGroups(pieces, quantum)
+	snapped := bufferSnap([]*Poly{poly}, quantum)
+	if distance < 0 {
+		return bufferSnap(overlay(snapped, edges, OverlayDifference, quantum),
+			quantum)
+	}
+	return bufferSnap(overlay(snapped, edges, OverlayUnion, quantum), quantum)
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func expectArea(t *testing.T, polys []*Poly, area, tolerance float64) {
+	t.Helper()
+	if a := overlayArea(polys); math.Abs(a-area) > tolerance {
+		t.Fatalf("expected area %v, got %v", area, a)
+	}
+}
+
+func TestBufferPoint(t *testing.T) {
+	circle := P(5, 5).Buffer(1, nil)
+	expect(t, len(circle) == 1 && circle[0].Exterior.NumPoints() == 33)
+	// a 32-gon inside of the unit circle
+	expectArea(t, circle, 16*math.Sin(2*math.Pi/32), 1e-6)
+	expect(t, circle[0].Rect() == R(4, 4, 6, 6))
+	expectArea(t, P(5, 5).Buffer(1, &BufferOptions{CapStyle: CapSquare}), 4, 0)
+	expect(t, P(5, 5).Buffer(1, &BufferOptions{CapStyle: CapFlat}) == nil)
+	expect(t, P(5, 5).Buffer(0, nil) == nil)
+	expect(t, P(5, 5).Buffer(1, &BufferOptions{QuadrantSegments: 1})[0].
+		Exterior.NumPoints() == 5)
+}
+
+func TestBufferLine(t *testing.T) {
+	line := L(P(0, 0), P(10, 0))
+	flat := line.Buffer(1, &BufferOptions{CapStyle: CapFlat})
+	expect(t, len(flat) == 1 && flat[0].Rect() == R(0, -1, 10, 1))
+	expectArea(t, flat, 20, 1e-6)
+	expectArea(t, line.Buffer(1, &BufferOptions{CapStyle: CapSquare}), 24, 1e-6)
+	round := line.Buffer(1, nil)
+	expectArea(t, round, 20+16*math.Sin(2*math.Pi/32), 1e-6)
+	expect(t, round[0].Rect() == R(-1, -1, 11, 1))
+
+	// an L shape, with the outer corner at (10,-1)
+	el := L(P(0, 0), P(10, 0), P(10, 10))
+	opts := &BufferOptions{CapStyle: CapFlat, JoinStyle: JoinMitre}
+	mitre := el.Buffer(1, opts)
+	expect(t, len(mitre) == 1 && len(mitre[0].Holes) == 0)
+	expectArea(t, mitre, 40, 1e-6)
+	expect(t, mitre[0].Rect() == R(0, -1, 11, 10))
+	opts.JoinStyle = JoinBevel
+	expectArea(t, el.Buffer(1, opts), 39.5, 1e-6)
+	opts.JoinStyle = JoinRound
+	expectArea(t, el.Buffer(1, opts), 39+4*math.Sin(2*math.Pi/32), 1e-6)
+	// a sharp corner is beveled past the mitre limit
+	sharp := L(P(0, 0), P(10, 0), P(0, 0.5))
+	opts = &BufferOptions{CapStyle: CapFlat, JoinStyle: JoinMitre, MitreLimit: 2}
+	expect(t, sharp.Buffer(1, opts)[0].Rect().Max.X < 11.1)
+
+	// a closed line has a hole
+	ring := L(P(0, 0), P(10, 0), P(10, 10), P(0, 10), P(0, 0))
+	square := ring.Buffer(1, &BufferOptions{JoinStyle: JoinMitre})
+	expect(t, len(square) == 1 && len(square[0].Holes) == 1)
+	expectArea(t, square, 79+4*math.Sin(2*math.Pi/32), 1e-6)
+
+	// turning back
+	back := L(P(0, 0), P(10, 0), P(5, 0))
+	expectArea(t, back.Buffer(1, &BufferOptions{CapStyle: CapFlat,
+		JoinStyle: JoinBevel}), 20, 1e-6)
+	expect(t, back.Buffer(1, &BufferOptions{CapStyle: CapFlat})[0].
+		Rect() == R(0, -1, 11, 1))
+
+	expect(t, L(P(1, 1)).Buffer(1, nil)[0].Rect() == R(0, 0, 2, 2))
+	expect(t, L(P(1, 1), P(1, 1)).Buffer(1, nil)[0].Rect() == R(0, 0, 2, 2))
+	expect(t, (*Line)(nil).Buffer(1, nil) == nil)
+}
+
+func TestBufferPoly(t *testing.T) {
+	square := NewPoly(rectangle, nil, nil)
+	opts := &BufferOptions{JoinStyle: JoinMitre}
+	grown := square.Buffer(1, opts)
+	expect(t, len(grown) == 1 && len(grown[0].Holes) == 0)
+	expect(t, grown[0].Rect() == R(-1, -1, 11, 11))
+	expectArea(t, grown, 144, 1e-6)
+	shrunk := square.Buffer(-1, opts)
+	expect(t, len(shrunk) == 1 && shrunk[0].Rect() == R(1, 1, 9, 9))
+	expectArea(t, shrunk, 64, 1e-6)
+	expect(t, len(square.Buffer(-6, opts)) == 0)
+	expect(t, square.Buffer(0, nil)[0] == square)
+	expectArea(t, square.Buffer(1, nil), 140+4*16*math.Sin(2*math.Pi/32)/4, 1e-6)
+
+	// holes grow when shrinking
+	hole := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}
+	holed := NewPoly(rectangle, [][]Point{hole}, nil)
+	expectArea(t, holed.Buffer(-1, opts), 64-16, 1e-6)
+	// and fill in when growing
+	filled := holed.Buffer(1, opts)
+	expect(t, len(filled[0].Holes) == 0)
+	expectArea(t, filled, 144, 1e-6)
+
+	// a concave polygon shrinks into two parts
+	c := NewPoly([]Point{{0, 0}, {10, 0}, {10, 10}, {6, 10}, {6, 1}, {4, 1},
+		{4, 10}, {0, 10}, {0, 0}}, nil, nil)
+	expect(t, len(c.Buffer(-1.5, opts)) == 2)
+
+	texas := NewPoly(tx, nil, nil)
+	big := texas.Buffer(0.1, nil)
+	expect(t, len(big) == 1 && big[0].Area() > texas.Area())
+	small := texas.Buffer(-0.1, nil)
+	expect(t, overlayArea(small) < texas.Area())
+}
+
+func TestUnionPolys(t *testing.T) {
+	var polys []*Poly
+	for i := 0; i < 10; i++ {
+		x := float64(i * 5)
+		polys = append(polys, NewPoly([]Point{{x, 0}, {x + 10, 0}, {x + 10, 10},
+			{x, 10}, {x, 0}}, nil, nil))
+	}
+	union := UnionPolys(polys)
+	expect(t, len(union) == 1 && union[0].Rect() == R(0, 0, 55, 10))
+	expectArea(t, union, 550, 0)
+	expect(t, len(UnionPolys(nil)) == 0)
+
+	// points that are on the other polygon's edges, give or take a rounding
+	// error
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		a, b := randomStarGrid(r, 0.1), randomStarGrid(r, 0.1)
+		inter := overlayArea(a.Intersection(b))
+		union := overlayArea(UnionPolys([]*Poly{a, b}))
+		expect(t, math.Abs(union-(a.Area()+b.Area()-inter)) < 1e-6)
+	}
+}
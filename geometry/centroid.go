@@ -0,0 +1,241 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// seriesAreaCentroid returns the centroid and the unsigned area of the shape
+// formed by a closed series. Points are made relative to the first point for
+// numerical stability.
+func seriesAreaCentroid(series Series) (Point, float64) {
+	n := series.NumPoints()
+	if n == 0 {
+		return Point{}, 0
+	}
+	origin := series.PointAt(0)
+	var cx, cy, sum float64
+	for i := 0; i < n; i++ {
+		a, b := series.PointAt(i), series.PointAt((i+1)%n)
+		ax, ay := a.X-origin.X, a.Y-origin.Y
+		bx, by := b.X-origin.X, b.Y-origin.Y
+		cross := ax*by - bx*ay
+		cx += (ax + bx) * cross
+		cy += (ay + by) * cross
+		sum += cross
+	}
+	if sum == 0 {
+		return origin, 0
+	}
+	return Point{X: origin.X + cx/(3*sum), Y: origin.Y + cy/(3*sum)},
+		math.Abs(sum) / 2
+}
+
+// seriesLengthCentroid returns the length weighted centroid of the segments
+// in a series and their total length.
+func seriesLengthCentroid(series Series) (Point, float64) {
+	if series.NumPoints() == 0 {
+		return Point{}, 0
+	}
+	var cx, cy, length float64
+	n := series.NumSegments()
+	for i := 0; i < n; i++ {
+		seg := series.SegmentAt(i)
+		l := math.Hypot(seg.B.X-seg.A.X, seg.B.Y-seg.A.Y)
+		cx += (seg.A.X + seg.B.X) / 2 * l
+		cy += (seg.A.Y + seg.B.Y) / 2 * l
+		length += l
+	}
+	if length == 0 {
+		return series.PointAt(0), 0
+	}
+	return Point{X: cx / length, Y: cy / length}, length
+}
+
+// Centroid returns the length weighted centroid of the line.
+func (line *Line) Centroid() Point {
+	if line == nil {
+		return Point{}
+	}
+	centroid, _ := seriesLengthCentroid(line)
+	return centroid
+}
+
+// Centroid returns the area weighted centroid of the polygon, with the holes
+// subtracted. A polygon without area uses the centroid of its exterior edges.
+func (poly *Poly) Centroid() Point {
+	if poly.Empty() {
+		return Point{}
+	}
+	centroid, area := seriesAreaCentroid(poly.Exterior)
+	cx, cy := centroid.X*area, centroid.Y*area
+	for _, hole := range poly.Holes {
+		hc, harea := seriesAreaCentroid(hole)
+		cx -= hc.X * harea
+		cy -= hc.Y * harea
+		area -= harea
+	}
+	if area <= 0 {
+		centroid, _ = seriesLengthCentroid(poly.Exterior)
+		return centroid
+	}
+	return Point{X: cx / area, Y: cy / area}
+}
+
+// PointOnSurface returns a point that is guaranteed to be inside of the
+// polygon, unless the polygon has no area.
+func (poly *Poly) PointOnSurface() Point {
+	point, _ := poly.scanlineInterior()
+	return point
+}
+
+// scanlineInterior returns the midpoint of the widest interior interval on a
+// horizontal line that passes near the center of the polygon, and the width
+// of that interval. The line is placed between vertices so that it never
+// crosses one.
+func (poly *Poly) scanlineInterior() (Point, float64) {
+	if poly.Empty() {
+		return Point{}, 0
+	}
+	rings := polyRings(poly)
+	rect := poly.Rect()
+	centerY := (rect.Min.Y + rect.Max.Y) / 2
+	loY, hiY := rect.Min.Y, rect.Max.Y
+	for _, ring := range rings {
+		for i := 0; i < ring.NumPoints(); i++ {
+			y := ring.PointAt(i).Y
+			if y <= centerY {
+				loY = math.Max(loY, y)
+			} else {
+				hiY = math.Min(hiY, y)
+			}
+		}
+	}
+	y := (loY + hiY) / 2
+	var xs []float64
+	for _, ring := range rings {
+		ring.Search(Rect{
+			Min: Point{X: rect.Min.X, Y: y},
+			Max: Point{X: rect.Max.X, Y: y},
+		}, func(seg Segment, _ int) bool {
+			if (seg.A.Y > y) != (seg.B.Y > y) {
+				xs = append(xs, seg.A.X+
+					(y-seg.A.Y)*(seg.B.X-seg.A.X)/(seg.B.Y-seg.A.Y))
+			}
+			return true
+		})
+	}
+	if len(xs) < 2 {
+		return poly.Exterior.PointAt(0), 0
+	}
+	sort.Float64s(xs)
+	var point Point
+	width := -1.0
+	for i := 0; i+1 < len(xs); i += 2 {
+		if w := xs[i+1] - xs[i]; w > width {
+			width = w
+			point = Point{X: (xs[i] + xs[i+1]) / 2, Y: y}
+		}
+	}
+	return point, width
+}
+
+// poleCell is a square cell used to search for a pole of inaccessibility.
+type poleCell struct {
+	center Point
+	half   float64 // half the cell size
+	dist   float64 // signed distance from the center to the polygon edge
+	max    float64 // max distance to the polygon edge within the cell
+}
+
+type poleQueue []poleCell
+
+func (q poleQueue) Len() int            { return len(q) }
+func (q poleQueue) Less(i, j int) bool  { return q[i].max > q[j].max }
+func (q poleQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *poleQueue) Push(x interface{}) { *q = append(*q, x.(poleCell)) }
+func (q *poleQueue) Pop() interface{} {
+	old := *q
+	cell := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return cell
+}
+
+// edgeDistance returns the distance from a point to the nearest polygon edge,
+// which is negative when the point is outside of the polygon.
+func (poly *Poly) edgeDistance(point Point) float64 {
+	best := math.Inf(+1)
+	for _, ring := range polyRings(poly) {
+		_, dist := seriesNearestPoint(ring, point)
+		best = math.Min(best, dist)
+	}
+	best = math.Sqrt(best)
+	if !poly.ContainsPoint(point) {
+		best = -best
+	}
+	return best
+}
+
+func (poly *Poly) newPoleCell(center Point, half float64) poleCell {
+	dist := poly.edgeDistance(center)
+	return poleCell{center, half, dist, dist + half*math.Sqrt2}
+}
+
+// PoleOfInaccessibility returns the point inside of the polygon that is
+// farthest from its edges, and the distance from that point to the nearest
+// edge. This is the best place for a label. The result is within precision
+// coordinate units of the true pole. A precision of zero or less uses one
+// thousandth of the larger side of the polygon rect.
+func (poly *Poly) PoleOfInaccessibility(precision float64) (Point, float64) {
+	if poly.Empty() {
+		return Point{}, 0
+	}
+	// see https://github.com/mapbox/polylabel
+	rect := poly.Rect()
+	width, height := rect.Max.X-rect.Min.X, rect.Max.Y-rect.Min.Y
+	if precision <= 0 {
+		precision = math.Max(width, height) / 1000
+	}
+	size := math.Min(width, height)
+	if size == 0 || precision == 0 {
+		return poly.PointOnSurface(), 0
+	}
+	// cover the polygon with the initial cells
+	var queue poleQueue
+	half := size / 2
+	for x := rect.Min.X; x < rect.Max.X; x += size {
+		for y := rect.Min.Y; y < rect.Max.Y; y += size {
+			queue = append(queue,
+				poly.newPoleCell(Point{X: x + half, Y: y + half}, half))
+		}
+	}
+	heap.Init(&queue)
+	// start with the interior point, which is always inside
+	best := poly.newPoleCell(poly.PointOnSurface(), 0)
+	if cell := poly.newPoleCell(poly.Centroid(), 0); cell.dist > best.dist {
+		best = cell
+	}
+	for queue.Len() > 0 {
+		cell := heap.Pop(&queue).(poleCell)
+		if cell.dist > best.dist {
+			best = cell
+		}
+		if cell.max-best.dist <= precision {
+			continue
+		}
+		// split the cell into four
+		half := cell.half / 2
+		for _, d := range [4][2]float64{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+			heap.Push(&queue, poly.newPoleCell(Point{
+				X: cell.center.X + d[0]*half,
+				Y: cell.center.Y + d[1]*half,
+			}, half))
+		}
+	}
+	return best.center, best.dist
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCentroid(t *testing.T) {
+	expect(t, L(P(0, 0), P(10, 0)).Centroid() == P(5, 0))
+	// the long segment pulls the centroid
+	expect(t, L(P(0, 0), P(0, 2), P(8, 2)).Centroid() == P(3.2, 1.8))
+	expect(t, L(P(1, 1), P(1, 1)).Centroid() == P(1, 1))
+	expect(t, (*Line)(nil).Centroid() == P(0, 0))
+
+	dualPolyTest(t, rectangle, nil, func(t *testing.T, poly *Poly) {
+		expect(t, poly.Centroid() == P(5, 5))
+	})
+	// a hole on the right side pushes the centroid left
+	hole := []Point{{6, 4}, {8, 4}, {8, 6}, {6, 6}, {6, 4}}
+	dualPolyTest(t, rectangle, [][]Point{hole}, func(t *testing.T, poly *Poly) {
+		c := poly.Centroid()
+		expect(t, math.Abs(c.X-(500-7*4)/96.0) < 1e-9 && c.Y == 5)
+	})
+	// reversed winding
+	expect(t, NewPoly([]Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		nil, nil).Centroid() == P(5, 5))
+	// far from the origin
+	c := (&Poly{Exterior: R(1e9, 1e9, 1e9+2, 1e9+2)}).Centroid()
+	expect(t, c == P(1e9+1, 1e9+1))
+	// the centroid of a C-shape is outside
+	cshape := []Point{{0, 0}, {10, 0}, {10, 2}, {2, 2}, {2, 8}, {10, 8},
+		{10, 10}, {0, 10}, {0, 0}}
+	poly := NewPoly(cshape, nil, nil)
+	expect(t, !poly.ContainsPoint(poly.Centroid()))
+	// flat polygons use their edges
+	expect(t, NewPoly([]Point{{0, 0}, {10, 0}, {5, 0}, {0, 0}}, nil, nil).
+		Centroid() == P(5, 0))
+	expect(t, (&Poly{}).Centroid() == P(0, 0))
+}
+
+func TestPointOnSurface(t *testing.T) {
+	cshape := []Point{{0, 0}, {10, 0}, {10, 2}, {2, 2}, {2, 8}, {10, 8},
+		{10, 10}, {0, 10}, {0, 0}}
+	for _, ring := range [][]Point{cshape, octagon, concave1, concave2,
+		concave3, concave4, bowtie, pentagon, triangle} {
+		dualPolyTest(t, ring, nil, func(t *testing.T, poly *Poly) {
+			expect(t, poly.ContainsPoint(poly.PointOnSurface()))
+		})
+	}
+	// not inside of a hole
+	hole := []Point{{1, 1}, {9, 1}, {9, 9}, {1, 9}, {1, 1}}
+	poly := NewPoly(rectangle, [][]Point{hole}, nil)
+	p := poly.PointOnSurface()
+	expect(t, poly.ContainsPoint(p) && !hole2poly(hole).ContainsPoint(p))
+	expect(t, NewPoly(tx, nil, nil).ContainsPoint(NewPoly(tx, nil, nil).PointOnSurface()))
+	expect(t, (&Poly{}).PointOnSurface() == P(0, 0))
+}
+
+func hole2poly(ring []Point) *Poly {
+	return NewPoly(ring, nil, nil)
+}
+
+func TestPoleOfInaccessibility(t *testing.T) {
+	p, d := NewPoly(rectangle, nil, nil).PoleOfInaccessibility(0.001)
+	expect(t, math.Abs(p.X-5) < 0.01 && math.Abs(p.Y-5) < 0.01)
+	expect(t, math.Abs(d-5) < 0.01)
+
+	// the pole of a C-shape is in its corners
+	cshape := []Point{{0, 0}, {10, 0}, {10, 2}, {2, 2}, {2, 8}, {10, 8},
+		{10, 10}, {0, 10}, {0, 0}}
+	poly := NewPoly(cshape, nil, nil)
+	p, d = poly.PoleOfInaccessibility(0)
+	expect(t, poly.ContainsPoint(p) && p.X < 2 && d > 1.1)
+
+	poly = NewPoly(tx, nil, DefaultIndexOptions)
+	p, d = poly.PoleOfInaccessibility(0)
+	expect(t, poly.ContainsPoint(p) && d > 0)
+	_, d2 := poly.PoleOfInaccessibility(0.5)
+	expect(t, d >= d2-0.5)
+
+	p, d = (&Poly{}).PoleOfInaccessibility(0)
+	expect(t, p == P(0, 0) && d == 0)
+	p, _ = NewPoly([]Point{{0, 0}, {10, 0}, {5, 0}, {0, 0}}, nil, nil).
+		PoleOfInaccessibility(0)
+	expect(t, p == P(0, 0))
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+// clipSegment clips a segment to a rectangle using the Liang–Barsky
+// algorithm. Returns false when no part of the segment is inside.
+func clipSegment(seg Segment, rect Rect) (Segment, bool) {
+	dx, dy := seg.B.X-seg.A.X, seg.B.Y-seg.A.Y
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, seg.A.X - rect.Min.X},
+		{dx, rect.Max.X - seg.A.X},
+		{-dy, seg.A.Y - rect.Min.Y},
+		{dy, rect.Max.Y - seg.A.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return seg, false
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return seg, false
+			}
+			if r > t0 {
+				t0 = r
+			}
+		} else {
+			if r < t0 {
+				return seg, false
+			}
+			if r < t1 {
+				t1 = r
+			}
+		}
+	}
+	clipped := seg
+	if t0 > 0 {
+		clipped.A = Point{X: seg.A.X + t0*dx, Y: seg.A.Y + t0*dy}
+	}
+	if t1 < 1 {
+		clipped.B = Point{X: seg.A.X + t1*dx, Y: seg.A.Y + t1*dy}
+	}
+	return clipped, true
+}
+
+// ClipToRect returns the parts of the line that are inside of a rectangle.
+// A line that leaves and reenters the rectangle is split into more than one
+// part.
+func (line *Line) ClipToRect(rect Rect) [][]Point {
+	if line == nil || line.Empty() || !rect.IntersectsRect(line.Rect()) {
+		return nil
+	}
+	if rect.ContainsRect(line.Rect()) {
+		points := make([]Point, line.NumPoints())
+		for i := range points {
+			points[i] = line.PointAt(i)
+		}
+		return [][]Point{points}
+	}
+	var parts [][]Point
+	var part []Point
+	for i := 0; i < line.NumSegments(); i++ {
+		seg, ok := clipSegment(line.SegmentAt(i), rect)
+		if !ok {
+			continue
+		}
+		if len(part) == 0 || part[len(part)-1] != seg.A {
+			if len(part) > 1 {
+				parts = append(parts, part)
+			}
+			part = []Point{seg.A}
+		}
+		if seg.B != seg.A {
+			part = append(part, seg.B)
+		}
+	}
+	if len(part) > 1 {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// clipRing clips the points of a ring to one side of a rectangle using the
+// Sutherland–Hodgman algorithm. The inside func returns true for points that
+// are on the kept side, and the cross func returns the point where a segment
+// crosses the side.
+func clipRing(points []Point, inside func(p Point) bool,
+	cross func(a, b Point) Point,
+) []Point {
+	var clipped []Point
+	for i, b := range points {
+		a := points[(i+len(points)-1)%len(points)]
+		switch {
+		case inside(b):
+			if !inside(a) {
+				clipped = append(clipped, cross(a, b))
+			}
+			clipped = append(clipped, b)
+		case inside(a):
+			clipped = append(clipped, cross(a, b))
+		}
+	}
+	return clipped
+}
+
+// clipRingToRect returns the points of a ring that is clipped to a
+// rectangle, or nil when no part of the ring is inside. The returned ring is
+// closed.
+func clipRingToRect(ring Ring, rect Rect) []Point {
+	n := ring.NumSegments()
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		points[i] = ring.SegmentAt(i).A
+	}
+	crossX := func(x float64) func(a, b Point) Point {
+		return func(a, b Point) Point {
+			return Point{X: x, Y: a.Y + (x-a.X)*(b.Y-a.Y)/(b.X-a.X)}
+		}
+	}
+	crossY := func(y float64) func(a, b Point) Point {
+		return func(a, b Point) Point {
+			return Point{X: a.X + (y-a.Y)*(b.X-a.X)/(b.Y-a.Y), Y: y}
+		}
+	}
+	points = clipRing(points, func(p Point) bool { return p.X >= rect.Min.X },
+		crossX(rect.Min.X))
+	points = clipRing(points, func(p Point) bool { return p.X <= rect.Max.X },
+		crossX(rect.Max.X))
+	points = clipRing(points, func(p Point) bool { return p.Y >= rect.Min.Y },
+		crossY(rect.Min.Y))
+	points = clipRing(points, func(p Point) bool { return p.Y <= rect.Max.Y },
+		crossY(rect.Max.Y))
+	if len(points) < 3 {
+		return nil
+	}
+	return append(points, points[0])
+}
+
+// ClipToRect returns the part of the polygon that is inside of a rectangle,
+// or nil when no part is inside. A polygon that is concave may leave behind
+// zero-width edges along the sides of the rectangle, which is usually
+// acceptable for rendering and tiling.
+func (poly *Poly) ClipToRect(rect Rect) *Poly {
+	if poly == nil || poly.Empty() || !rect.IntersectsRect(poly.Rect()) {
+		return nil
+	}
+	if rect.ContainsRect(poly.Rect()) {
+		return poly
+	}
+	exterior := clipRingToRect(poly.Exterior, rect)
+	if exterior == nil {
+		return nil
+	}
+	var holes [][]Point
+	for _, hole := range poly.Holes {
+		if hole := clipRingToRect(hole, rect); hole != nil {
+			holes = append(holes, hole)
+		}
+	}
+	clipped := NewPoly(exterior, holes, DefaultIndexOptions)
+	if clipped.Area() == 0 {
+		// only touches the rectangle, or is inside of a hole
+		return nil
+	}
+	return clipped
+}
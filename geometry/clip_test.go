@@ -0,0 +1,69 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClipLine(t *testing.T) {
+	rect := R(0, 0, 10, 10)
+	expect(t, reflect.DeepEqual(L(P(-5, 5), P(15, 5)).ClipToRect(rect),
+		[][]Point{{{0, 5}, {10, 5}}}))
+	// leaving and reentering makes two parts
+	expect(t, reflect.DeepEqual(L(u1...).ClipToRect(R(-1, 5, 11, 11)),
+		[][]Point{{{0, 10}, {0, 5}}, {{10, 5}, {10, 10}}}))
+	expect(t, reflect.DeepEqual(L(P(2, 2), P(5, 5), P(15, 5)).ClipToRect(rect),
+		[][]Point{{{2, 2}, {5, 5}, {10, 5}}}))
+	// inside and outside
+	expect(t, reflect.DeepEqual(L(v1...).ClipToRect(R(-1, -1, 11, 11)),
+		[][]Point{v1}))
+	expect(t, L(P(20, 20), P(30, 30)).ClipToRect(rect) == nil)
+	expect(t, L(P(-5, 30), P(30, -5)).ClipToRect(rect) == nil)
+	// touching a corner
+	expect(t, reflect.DeepEqual(L(P(-5, 15), P(5, 5)).ClipToRect(rect),
+		[][]Point{{{0, 10}, {5, 5}}}))
+	expect(t, L(P(-5, 15), P(15, 5)).ClipToRect(R(0, 0, 10, 10)) != nil)
+	expect(t, (*Line)(nil).ClipToRect(rect) == nil)
+}
+
+func TestClipPoly(t *testing.T) {
+	dualPolyTest(t, octagon, nil, func(t *testing.T, poly *Poly) {
+		expect(t, poly.ClipToRect(R(-1, -1, 11, 11)) == poly)
+		expect(t, poly.ClipToRect(R(20, 20, 30, 30)) == nil)
+		clipped := poly.ClipToRect(R(0, 0, 5, 5))
+		expect(t, clipped.Rect() == R(0, 0, 5, 5))
+		expect(t, clipped.Area() == 25-4.5)
+		expect(t, clipped.ContainsPoint(P(4, 4)) && !clipped.ContainsPoint(P(0.5, 0.5)))
+	})
+	// holes are clipped too
+	hole := []Point{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}}
+	dualPolyTest(t, rectangle, [][]Point{hole}, func(t *testing.T, poly *Poly) {
+		clipped := poly.ClipToRect(R(5, -5, 15, 15))
+		expect(t, clipped.Area() == 50-18)
+		expect(t, len(clipped.Holes) == 1)
+		expect(t, !clipped.ContainsPoint(P(6, 5)) && clipped.ContainsPoint(P(9, 5)))
+		// the rectangle is inside of the hole
+		expect(t, poly.ClipToRect(R(3, 3, 7, 7)) == nil)
+	})
+	// only touching the side
+	expect(t, NewPoly(rectangle, nil, nil).ClipToRect(R(10, 0, 20, 10)) == nil)
+	// a concave polygon
+	c := NewPoly(concave1, nil, nil).ClipToRect(R(0, 0, 10, 5))
+	expect(t, c.Area() == 25)
+	expect(t, (*Poly)(nil).ClipToRect(R(0, 0, 1, 1)) == nil)
+}
+
+func TestClipStates(t *testing.T) {
+	texas := NewPoly(tx, nil, DefaultIndexOptions)
+	rect := texas.Rect()
+	mid := (rect.Min.X + rect.Max.X) / 2
+	west := texas.ClipToRect(R(rect.Min.X-1, rect.Min.Y-1, mid, rect.Max.Y+1))
+	east := texas.ClipToRect(R(mid, rect.Min.Y-1, rect.Max.X+1, rect.Max.Y+1))
+	expect(t, west.Rect().Max.X == mid && east.Rect().Min.X == mid)
+	area := west.Area() + east.Area()
+	expect(t, area > texas.Area()*0.999999 && area < texas.Area()*1.000001)
+}
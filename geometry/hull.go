@@ -0,0 +1,389 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// uniquePoints returns the points sorted by X and then Y, without repeats.
+func uniquePoints(points []Point) []Point {
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	var unique []Point
+	for i, point := range sorted {
+		if i == 0 || point != sorted[i-1] {
+			unique = append(unique, point)
+		}
+	}
+	return unique
+}
+
+// ConvexHull returns the smallest convex ring that contains all of the
+// points, using Andrew's monotone chain algorithm. The ring is
+// counter-clockwise and closed, and has no collinear points. When all of the
+// points are on a line, the two ends of the line are returned, and when all
+// of the points are the same, one point is returned.
+func ConvexHull(points []Point) []Point {
+	unique := uniquePoints(points)
+	if len(unique) < 3 {
+		return unique
+	}
+	hull := make([]Point, 0, len(unique)+1)
+	// lower chain
+	for _, point := range unique {
+		for len(hull) >= 2 &&
+			cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, point)
+	}
+	// upper chain
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		point := unique[i]
+		for len(hull) >= lower &&
+			cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, point)
+	}
+	if len(hull) < 4 {
+		// collinear, which is the first point, the last point, and the first
+		// point again
+		return hull[:2]
+	}
+	return hull
+}
+
+// delaunayTri is a counter-clockwise triangle of a Delaunay triangulation.
+// The neighbor at index i is across the edge that is opposite of the vertex
+// at index i, or -1 when there is no neighbor.
+type delaunayTri struct {
+	v    [3]int
+	n    [3]int
+	dead bool
+}
+
+// delaunay is a Delaunay triangulation that is built with the Bowyer–Watson
+// algorithm, starting with a triangle that is much larger than the points.
+type delaunay struct {
+	points []Point
+	tris   []delaunayTri
+	last   int
+}
+
+func (d *delaunay) orient(a, b, c int) float64 {
+	return cross(d.points[a], d.points[b], d.points[c])
+}
+
+// inCircle returns true when a point is inside of the circumcircle of a
+// triangle.
+func (d *delaunay) inCircle(t int, p Point) bool {
+	tri := &d.tris[t]
+	a, b, c := d.points[tri.v[0]], d.points[tri.v[1]], d.points[tri.v[2]]
+	ax, ay := a.X-p.X, a.Y-p.Y
+	bx, by := b.X-p.X, b.Y-p.Y
+	cx, cy := c.X-p.X, c.Y-p.Y
+	return (ax*ax+ay*ay)*(bx*cy-cx*by)-
+		(bx*bx+by*by)*(ax*cy-cx*ay)+
+		(cx*cx+cy*cy)*(ax*by-bx*ay) > 0
+}
+
+// locate returns a triangle that contains a point, by walking across the
+// triangles from the last one that was made.
+func (d *delaunay) locate(p int) int {
+	t := d.last
+	for steps := 0; steps < len(d.tris); steps++ {
+		tri := &d.tris[t]
+		next := -1
+		for i := 0; i < 3; i++ {
+			if d.orient(tri.v[(i+1)%3], tri.v[(i+2)%3], p) < 0 {
+				next = tri.n[i]
+				break
+			}
+		}
+		if next == -1 {
+			return t
+		}
+		t = next
+	}
+	// the walk went in circles, which can happen with floating point errors
+	for t := range d.tris {
+		if !d.tris[t].dead && d.inCircle(t, d.points[p]) {
+			return t
+		}
+	}
+	return d.last
+}
+
+// insert adds a point to the triangulation by replacing the triangles whose
+// circumcircles contain the point with triangles that fan out from it.
+func (d *delaunay) insert(p int) {
+	start := d.locate(p)
+	bad := map[int]bool{start: true}
+	cavity := []int{start}
+	for i := 0; i < len(cavity); i++ {
+		for _, n := range d.tris[cavity[i]].n {
+			if n != -1 && !bad[n] && d.inCircle(n, d.points[p]) {
+				bad[n] = true
+				cavity = append(cavity, n)
+			}
+		}
+	}
+	// new triangles by the first and second vertex of their outer edge
+	byA, byB := make(map[int]int), make(map[int]int)
+	var made []int
+	for _, t := range cavity {
+		tri := d.tris[t]
+		for i := 0; i < 3; i++ {
+			outer := tri.n[i]
+			if outer != -1 && bad[outer] {
+				continue
+			}
+			a, b := tri.v[(i+1)%3], tri.v[(i+2)%3]
+			nt := len(d.tris)
+			d.tris = append(d.tris, delaunayTri{
+				v: [3]int{a, b, p},
+				n: [3]int{-1, -1, outer},
+			})
+			if outer != -1 {
+				for j := 0; j < 3; j++ {
+					if d.tris[outer].n[j] == t {
+						d.tris[outer].n[j] = nt
+					}
+				}
+			}
+			byA[a], byB[b] = nt, nt
+			made = append(made, nt)
+		}
+		d.tris[t].dead = true
+	}
+	for _, t := range made {
+		tri := &d.tris[t]
+		tri.n[0], tri.n[1] = -1, -1
+		if n, ok := byA[tri.v[1]]; ok {
+			tri.n[0] = n
+		}
+		if n, ok := byB[tri.v[0]]; ok {
+			tri.n[1] = n
+		}
+	}
+	d.last = made[0]
+}
+
+// newDelaunay returns the triangulation of unique points. The triangles that
+// use the vertices of the starting triangle are dead.
+func newDelaunay(points []Point) *delaunay {
+	rect := Rect{Min: points[0], Max: points[0]}
+	for _, point := range points {
+		rect.Min.X = math.Min(rect.Min.X, point.X)
+		rect.Min.Y = math.Min(rect.Min.Y, point.Y)
+		rect.Max.X = math.Max(rect.Max.X, point.X)
+		rect.Max.Y = math.Max(rect.Max.Y, point.Y)
+	}
+	center := rect.Center()
+	size := 100 * math.Max(rect.Max.X-rect.Min.X, rect.Max.Y-rect.Min.Y)
+	n := len(points)
+	d := &delaunay{points: append(points[:n:n],
+		Point{X: center.X - size, Y: center.Y - size},
+		Point{X: center.X + size, Y: center.Y - size},
+		Point{X: center.X, Y: center.Y + size},
+	)}
+	d.tris = append(d.tris, delaunayTri{
+		v: [3]int{n, n + 1, n + 2},
+		n: [3]int{-1, -1, -1},
+	})
+	for i := 0; i < n; i++ {
+		d.insert(i)
+	}
+	for t := range d.tris {
+		tri := &d.tris[t]
+		if tri.v[0] >= n || tri.v[1] >= n || tri.v[2] >= n {
+			tri.dead = true
+		}
+	}
+	for t := range d.tris {
+		tri := &d.tris[t]
+		for i, nt := range tri.n {
+			if nt != -1 && d.tris[nt].dead {
+				tri.n[i] = -1
+			}
+		}
+	}
+	return d
+}
+
+// hullTri is a triangle in the concave hull queue, by the length of its
+// longest border edge.
+type hullTri struct {
+	index  int
+	length float64
+}
+
+type hullQueue []hullTri
+
+func (q hullQueue) Len() int            { return len(q) }
+func (q hullQueue) Less(i, j int) bool  { return q[i].length > q[j].length }
+func (q hullQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *hullQueue) Push(x interface{}) { *q = append(*q, x.(hullTri)) }
+func (q *hullQueue) Pop() interface{} {
+	old := *q
+	tri := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return tri
+}
+
+// edgeLength returns the length of the edge of a triangle that is opposite
+// of the vertex at index i.
+func (d *delaunay) edgeLength(t, i int) float64 {
+	tri := &d.tris[t]
+	a, b := d.points[tri.v[(i+1)%3]], d.points[tri.v[(i+2)%3]]
+	return math.Sqrt(distSq(a, b))
+}
+
+// ConcaveHull returns a ring that contains all of the points, and follows
+// their shape more closely than the convex hull. The points are
+// triangulated, and then the triangles on the outside that have an edge that
+// is longer than a target length are removed, as long as no point is left
+// outside of the ring and the ring does not touch itself. The target length
+// is a ratio between the shortest and longest triangle edges, where 1 returns
+// the convex hull and 0 follows the points most closely. The ring is
+// counter-clockwise and closed. Points that are all on a line, or all the
+// same, are returned like ConvexHull does.
+func ConcaveHull(points []Point, ratio float64) []Point {
+	unique := uniquePoints(points)
+	if ratio >= 1 || len(unique) < 3 {
+		return ConvexHull(unique)
+	}
+	d := newDelaunay(unique)
+	var live []int
+	minLen, maxLen := math.Inf(+1), math.Inf(-1)
+	for t := range d.tris {
+		if d.tris[t].dead {
+			continue
+		}
+		live = append(live, t)
+		for i := 0; i < 3; i++ {
+			length := d.edgeLength(t, i)
+			minLen = math.Min(minLen, length)
+			maxLen = math.Max(maxLen, length)
+		}
+	}
+	if len(live) == 0 {
+		// collinear
+		return ConvexHull(unique)
+	}
+	target := minLen + math.Max(ratio, 0)*(maxLen-minLen)
+
+	// the points that are on the border of the remaining triangles
+	border := make([]bool, len(unique))
+	borderLength := func(t int) (length float64, edges int) {
+		for i, n := range d.tris[t].n {
+			if n == -1 {
+				length = math.Max(length, d.edgeLength(t, i))
+				edges++
+			}
+		}
+		return length, edges
+	}
+	var queue hullQueue
+	for _, t := range live {
+		tri := &d.tris[t]
+		for i, n := range tri.n {
+			if n == -1 {
+				border[tri.v[(i+1)%3]] = true
+				border[tri.v[(i+2)%3]] = true
+			}
+		}
+		if length, edges := borderLength(t); edges > 0 && length > target {
+			queue = append(queue, hullTri{t, length})
+		}
+	}
+	heap.Init(&queue)
+	for queue.Len() > 0 {
+		item := heap.Pop(&queue).(hullTri)
+		tri := &d.tris[item.index]
+		if tri.dead {
+			continue
+		}
+		length, edges := borderLength(item.index)
+		if length != item.length {
+			// stale
+			continue
+		}
+		// Only triangles with one border edge are removed, which keeps all
+		// of the points inside. The opposite point must not be on the
+		// border, which would make the ring touch itself.
+		if edges != 1 {
+			continue
+		}
+		var opposite int
+		for i, n := range tri.n {
+			if n == -1 {
+				opposite = tri.v[i]
+			}
+		}
+		if border[opposite] {
+			continue
+		}
+		tri.dead = true
+		border[opposite] = true
+		for _, n := range tri.n {
+			if n == -1 {
+				continue
+			}
+			other := &d.tris[n]
+			for j := 0; j < 3; j++ {
+				if other.n[j] == item.index {
+					other.n[j] = -1
+				}
+			}
+			if length, edges := borderLength(n); edges > 0 && length > target {
+				heap.Push(&queue, hullTri{n, length})
+			}
+		}
+	}
+
+	// join the border edges into a ring
+	next := make(map[int]int)
+	start := -1
+	for _, t := range live {
+		tri := &d.tris[t]
+		if tri.dead {
+			continue
+		}
+		for i, n := range tri.n {
+			if n == -1 {
+				a, b := tri.v[(i+1)%3], tri.v[(i+2)%3]
+				next[a] = b
+				if start == -1 {
+					start = a
+				}
+			}
+		}
+	}
+	ring := []Point{unique[start]}
+	for v := next[start]; v != start && len(ring) <= len(next); v = next[v] {
+		ring = append(ring, unique[v])
+	}
+	ring = removeCollinear(append(ring, unique[start]))
+	if ring == nil {
+		return ConvexHull(unique)
+	}
+	return ring
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestConvexHull(t *testing.T) {
+	square := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	expect(t, reflect.DeepEqual(ConvexHull(u1), square))
+	expect(t, reflect.DeepEqual(ConvexHull(append(u1, P(5, 5))), square))
+	hull := NewPoly(ConvexHull(octagon), nil, nil)
+	expect(t, hull.Area() == NewPoly(octagon, nil, nil).Area())
+	expect(t, !hull.Exterior.Clockwise())
+	// collinear points
+	expect(t, reflect.DeepEqual(ConvexHull([]Point{{1, 1}, {3, 3}, {2, 2}}),
+		[]Point{{1, 1}, {3, 3}}))
+	expect(t, reflect.DeepEqual(ConvexHull([]Point{{1, 1}, {1, 1}}),
+		[]Point{{1, 1}}))
+	expect(t, ConvexHull(nil) == nil)
+}
+
+// cShape returns the points of a grid in the shape of the letter C.
+func cShape() []Point {
+	var points []Point
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			if x < 3 || y < 3 || y > 7 {
+				points = append(points, P(float64(x), float64(y)))
+			}
+		}
+	}
+	return points
+}
+
+func TestConcaveHull(t *testing.T) {
+	points := cShape()
+	convex := ConvexHull(points)
+	expect(t, reflect.DeepEqual(ConcaveHull(points, 1), convex))
+	ring := ConcaveHull(points, 0)
+	poly := NewPoly(ring, nil, nil)
+	expect(t, poly.Area() == 100-8*6)
+	expect(t, !poly.Exterior.Clockwise())
+	for _, point := range points {
+		expect(t, poly.IntersectsPoint(point))
+	}
+	expect(t, !poly.ContainsPoint(P(6, 5)))
+	// a larger ratio fills in more
+	expect(t, NewPoly(ConcaveHull(points, 0.5), nil, nil).Area() > poly.Area())
+
+	// random points are always inside
+	r := rand.New(rand.NewSource(1))
+	var random []Point
+	for i := 0; i < 1000; i++ {
+		random = append(random, P(r.Float64()*100, r.Float64()*100))
+	}
+	for _, ratio := range []float64{0, 0.1, 0.3} {
+		poly := NewPoly(ConcaveHull(random, ratio), nil, nil)
+		expect(t, !seriesSelfIntersects(poly.Exterior, true))
+		for _, point := range random {
+			expect(t, poly.IntersectsPoint(point))
+		}
+	}
+
+	expect(t, reflect.DeepEqual(ConcaveHull([]Point{{1, 1}, {3, 3}, {2, 2}}, 0),
+		[]Point{{1, 1}, {3, 3}}))
+	expect(t, ConcaveHull(nil, 0) == nil)
+}
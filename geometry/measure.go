@@ -0,0 +1,63 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import "math"
+
+// seriesArea returns the planar area of the shape formed by a closed series.
+func seriesArea(series Series) float64 {
+	var sum float64
+	n := series.NumPoints()
+	for i := 0; i < n; i++ {
+		a, b := series.PointAt(i), series.PointAt((i+1)%n)
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return math.Abs(sum) / 2
+}
+
+// seriesLength returns the planar length of all segments in a series.
+func seriesLength(series Series) float64 {
+	var length float64
+	n := series.NumSegments()
+	for i := 0; i < n; i++ {
+		seg := series.SegmentAt(i)
+		length += math.Hypot(seg.B.X-seg.A.X, seg.B.Y-seg.A.Y)
+	}
+	return length
+}
+
+// Length returns the planar length of the line in coordinate units.
+func (line *Line) Length() float64 {
+	if line == nil {
+		return 0
+	}
+	return seriesLength(line)
+}
+
+// Area returns the planar area of the polygon in square coordinate units,
+// with the area of the holes subtracted.
+func (poly *Poly) Area() float64 {
+	if poly.Empty() {
+		return 0
+	}
+	area := seriesArea(poly.Exterior)
+	for _, hole := range poly.Holes {
+		area -= seriesArea(hole)
+	}
+	return area
+}
+
+// Length returns the planar perimeter of the polygon in coordinate units,
+// including the perimeter of the holes.
+func (poly *Poly) Length() float64 {
+	if poly.Empty() {
+		return 0
+	}
+	length := seriesLength(poly.Exterior)
+	for _, hole := range poly.Holes {
+		length += seriesLength(hole)
+	}
+	return length
+}
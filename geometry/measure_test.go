@@ -0,0 +1,27 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import "testing"
+
+func TestMeasure(t *testing.T) {
+	expect(t, L(u1...).Length() == 30)
+	expect(t, L(P(0, 0), P(3, 4)).Length() == 5)
+	expect(t, (*Line)(nil).Length() == 0)
+	small := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}
+	dualPolyTest(t, rectangle, [][]Point{small}, func(t *testing.T, poly *Poly) {
+		expect(t, poly.Area() == 96)
+		expect(t, poly.Length() == 48)
+	})
+	dualPolyTest(t, triangle, nil, func(t *testing.T, poly *Poly) {
+		expect(t, poly.Area() == 50)
+	})
+	// winding does not matter
+	expect(t, NewPoly([]Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		nil, nil).Area() == 100)
+	expect(t, (&Poly{Exterior: R(0, 0, 10, 5)}).Area() == 50)
+	expect(t, (&Poly{Exterior: R(0, 0, 10, 5)}).Length() == 30)
+	expect(t, (&Poly{}).Area() == 0 && (&Poly{}).Length() == 0)
+}
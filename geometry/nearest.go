@@ -0,0 +1,283 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import "math"
+
+// Nearest is the nearest location on a geometry to another location.
+type Nearest struct {
+	Point Point // the nearest point
+	Ring  int   // the polygon ring, where 0 is the exterior and 1+ are holes
+	Index int   // the segment index, or -1 if the point is not on a segment
+}
+
+func distSq(a, b Point) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// NearestPoint returns the point on the segment that is nearest to point.
+func (seg Segment) NearestPoint(point Point) Point {
+	dx, dy := seg.B.X-seg.A.X, seg.B.Y-seg.A.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return seg.A
+	}
+	t := ((point.X-seg.A.X)*dx + (point.Y-seg.A.Y)*dy) / lenSq
+	if t <= 0 {
+		return seg.A
+	}
+	if t >= 1 {
+		return seg.B
+	}
+	return Point{X: seg.A.X + t*dx, Y: seg.A.Y + t*dy}
+}
+
+// intersectionPoint returns a point that is shared by two segments which are
+// known to intersect.
+func (seg Segment) intersectionPoint(other Segment) Point {
+	rx, ry := seg.B.X-seg.A.X, seg.B.Y-seg.A.Y
+	sx, sy := other.B.X-other.A.X, other.B.Y-other.A.Y
+	rxs := rx*sy - ry*sx
+	if eqZero(rxs) {
+		// collinear, use any endpoint that is on the other segment
+		switch {
+		case seg.Raycast(other.A).On:
+			return other.A
+		case seg.Raycast(other.B).On:
+			return other.B
+		case other.Raycast(seg.A).On:
+			return seg.A
+		}
+		return seg.B
+	}
+	t := ((other.A.X-seg.A.X)*sy - (other.A.Y-seg.A.Y)*sx) / rxs
+	return Point{X: seg.A.X + t*rx, Y: seg.A.Y + t*ry}
+}
+
+// vertexSegment returns the index of a segment that starts or ends with the
+// vertex at index.
+func vertexSegment(series Series, index int) int {
+	n := series.NumSegments()
+	if index < n {
+		return index
+	}
+	return n - 1
+}
+
+// seriesNearestPoint returns the nearest location on a series to a point and
+// the squared distance between the two. The series segment index is searched
+// with a window that grows until it holds a segment that is nearer than the
+// window radius.
+func seriesNearestPoint(series Series, point Point) (Nearest, float64) {
+	if series.NumPoints() == 0 {
+		return Nearest{Index: -1}, math.Inf(+1)
+	}
+	nearest := Nearest{Point: series.PointAt(0), Index: vertexSegment(series, 0)}
+	best := distSq(point, nearest.Point)
+	if series.NumSegments() == 0 {
+		return nearest, best
+	}
+	// start with the distance to the series rect
+	rect := series.Rect()
+	r := math.Max(
+		distSq(point, Point{
+			X: math.Max(rect.Min.X, math.Min(rect.Max.X, point.X)),
+			Y: math.Max(rect.Min.Y, math.Min(rect.Max.Y, point.Y)),
+		}), best/4096)
+	for best > 0 {
+		if r > best {
+			r = best
+		}
+		d := math.Sqrt(r)
+		series.Search(Rect{
+			Min: Point{X: point.X - d, Y: point.Y - d},
+			Max: Point{X: point.X + d, Y: point.Y + d},
+		}, func(seg Segment, index int) bool {
+			near := seg.NearestPoint(point)
+			if dist := distSq(point, near); dist < best {
+				best = dist
+				nearest = Nearest{Point: near, Index: index}
+			}
+			return best > 0
+		})
+		if best <= r {
+			// every segment nearer than r was in the window
+			break
+		}
+		r *= 16
+	}
+	return nearest, best
+}
+
+// seriesClosestPair returns the nearest locations between two series and the
+// squared distance between the two.
+func seriesClosestPair(a, b Series) (na, nb Nearest, dist float64) {
+	na, nb = Nearest{Index: -1}, Nearest{Index: -1}
+	if a.NumPoints() == 0 || b.NumPoints() == 0 {
+		return na, nb, math.Inf(+1)
+	}
+	// look for an intersection
+	if a.Rect().IntersectsRect(b.Rect()) {
+		var found bool
+		for i := 0; i < a.NumSegments() && !found; i++ {
+			segA := a.SegmentAt(i)
+			b.Search(segA.Rect(), func(segB Segment, j int) bool {
+				if segA.IntersectsSegment(segB) {
+					point := segA.intersectionPoint(segB)
+					na = Nearest{Point: point, Index: i}
+					nb = Nearest{Point: point, Index: j}
+					found = true
+					return false
+				}
+				return true
+			})
+		}
+		if found {
+			return na, nb, 0
+		}
+	}
+	// otherwise the pair is always from a vertex of one series to a segment
+	// of the other.
+	dist = math.Inf(+1)
+	for i := 0; i < a.NumPoints(); i++ {
+		point := a.PointAt(i)
+		near, d := seriesNearestPoint(b, point)
+		if d < dist {
+			dist = d
+			na = Nearest{Point: point, Index: vertexSegment(a, i)}
+			nb = near
+		}
+	}
+	for i := 0; i < b.NumPoints(); i++ {
+		point := b.PointAt(i)
+		near, d := seriesNearestPoint(a, point)
+		if d < dist {
+			dist = d
+			na = near
+			nb = Nearest{Point: point, Index: vertexSegment(b, i)}
+		}
+	}
+	return na, nb, dist
+}
+
+func polyRings(poly *Poly) []Ring {
+	return append([]Ring{poly.Exterior}, poly.Holes...)
+}
+
+// RingNearestPoint returns the nearest location on the edge of a ring to a
+// point.
+func RingNearestPoint(ring Ring, point Point) Nearest {
+	nearest, _ := seriesNearestPoint(ring, point)
+	return nearest
+}
+
+// RingClosestPair returns the nearest locations between the edges of two
+// rings.
+func RingClosestPair(ring, other Ring) (a, b Nearest) {
+	a, b, _ = seriesClosestPair(ring, other)
+	return a, b
+}
+
+// NearestPoint returns the nearest location on the line to a point.
+func (line *Line) NearestPoint(point Point) Nearest {
+	if line == nil {
+		return Nearest{Index: -1}
+	}
+	nearest, _ := seriesNearestPoint(line, point)
+	return nearest
+}
+
+// ClosestPairLine returns the nearest locations between two lines.
+func (line *Line) ClosestPairLine(other *Line) (a, b Nearest) {
+	if line == nil || other == nil {
+		return Nearest{Index: -1}, Nearest{Index: -1}
+	}
+	a, b, _ = seriesClosestPair(line, other)
+	return a, b
+}
+
+// ClosestPairPoly returns the nearest locations between a line and a polygon.
+func (line *Line) ClosestPairPoly(poly *Poly) (a, b Nearest) {
+	b, a = poly.ClosestPairLine(line)
+	return a, b
+}
+
+// NearestPoint returns the nearest location on the polygon to a point. A
+// point that is inside of the polygon is its own nearest location and has an
+// Index of -1.
+func (poly *Poly) NearestPoint(point Point) Nearest {
+	if poly.Empty() {
+		return Nearest{Index: -1}
+	}
+	if poly.ContainsPoint(point) {
+		return Nearest{Point: point, Index: -1}
+	}
+	var nearest Nearest
+	best := math.Inf(+1)
+	for i, ring := range polyRings(poly) {
+		near, dist := seriesNearestPoint(ring, point)
+		if dist < best {
+			best = dist
+			nearest = near
+			nearest.Ring = i
+		}
+	}
+	return nearest
+}
+
+// ClosestPairLine returns the nearest locations between a polygon and a
+// line. When the line starts inside of the polygon then the polygon location
+// has an Index of -1.
+func (poly *Poly) ClosestPairLine(line *Line) (a, b Nearest) {
+	a, b = Nearest{Index: -1}, Nearest{Index: -1}
+	if poly.Empty() || line == nil || line.Empty() {
+		return a, b
+	}
+	if first := line.PointAt(0); poly.ContainsPoint(first) {
+		return Nearest{Point: first, Index: -1},
+			Nearest{Point: first, Index: vertexSegment(line, 0)}
+	}
+	best := math.Inf(+1)
+	for i, ring := range polyRings(poly) {
+		na, nb, dist := seriesClosestPair(ring, line)
+		if dist < best {
+			best = dist
+			a, b = na, nb
+			a.Ring = i
+		}
+	}
+	return a, b
+}
+
+// ClosestPairPoly returns the nearest locations between two polygons. When
+// one polygon starts inside of the other then the location on the outer
+// polygon has an Index of -1.
+func (poly *Poly) ClosestPairPoly(other *Poly) (a, b Nearest) {
+	a, b = Nearest{Index: -1}, Nearest{Index: -1}
+	if poly.Empty() || other.Empty() {
+		return a, b
+	}
+	if first := other.Exterior.PointAt(0); poly.ContainsPoint(first) {
+		return Nearest{Point: first, Index: -1},
+			Nearest{Point: first, Index: vertexSegment(other.Exterior, 0)}
+	}
+	if first := poly.Exterior.PointAt(0); other.ContainsPoint(first) {
+		return Nearest{Point: first, Index: vertexSegment(poly.Exterior, 0)},
+			Nearest{Point: first, Index: -1}
+	}
+	best := math.Inf(+1)
+	for i, ringA := range polyRings(poly) {
+		for j, ringB := range polyRings(other) {
+			na, nb, dist := seriesClosestPair(ringA, ringB)
+			if dist < best {
+				best = dist
+				a, b = na, nb
+				a.Ring, b.Ring = i, j
+			}
+		}
+	}
+	return a, b
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentNearestPoint(t *testing.T) {
+	expect(t, S(0, 0, 10, 0).NearestPoint(P(5, 5)) == P(5, 0))
+	expect(t, S(0, 0, 10, 0).NearestPoint(P(-5, 5)) == P(0, 0))
+	expect(t, S(0, 0, 10, 0).NearestPoint(P(15, -5)) == P(10, 0))
+	expect(t, S(0, 0, 0, 0).NearestPoint(P(15, -5)) == P(0, 0))
+	expect(t, S(0, 0, 10, 10).intersectionPoint(S(0, 10, 10, 0)) == P(5, 5))
+	expect(t, S(0, 0, 10, 0).intersectionPoint(S(5, 0, 15, 0)) == P(5, 0))
+	expect(t, S(5, 0, 15, 0).intersectionPoint(S(0, 0, 10, 0)) == P(10, 0))
+}
+
+func TestLineNearestPoint(t *testing.T) {
+	line := L(u1...)
+	expect(t, line.NearestPoint(P(5, 5)) == Nearest{P(0, 5), 0, 0})
+	expect(t, line.NearestPoint(P(5, -5)) == Nearest{P(5, 0), 0, 1})
+	expect(t, line.NearestPoint(P(12, 12)) == Nearest{P(10, 10), 0, 2})
+	expect(t, line.NearestPoint(P(10, 5)) == Nearest{P(10, 5), 0, 2})
+	expect(t, (*Line)(nil).NearestPoint(P(0, 0)).Index == -1)
+
+	// an indexed line must agree with a brute force search
+	for _, kind := range []IndexKind{None, RTree, QuadTree} {
+		line := NewLine(tx, &IndexOptions{Kind: kind, MinPoints: 1})
+		for i := 0; i < 100; i++ {
+			point := P(rand.Float64()*30-110, rand.Float64()*20+22)
+			best := math.Inf(+1)
+			for j := 0; j < line.NumSegments(); j++ {
+				best = math.Min(best,
+					distSq(point, line.SegmentAt(j).NearestPoint(point)))
+			}
+			near := line.NearestPoint(point)
+			expect(t, distSq(point, near.Point) == best)
+			expect(t, line.SegmentAt(near.Index).NearestPoint(point) == near.Point)
+		}
+	}
+}
+
+func TestRingNearestPoint(t *testing.T) {
+	expect(t, RingNearestPoint(R(0, 0, 10, 10), P(5, 5)).Point == P(5, 0))
+	expect(t, RingNearestPoint(R(0, 0, 10, 10), P(5, 12)) == Nearest{P(5, 10), 0, 2})
+	a, b := RingClosestPair(R(0, 0, 10, 10), R(12, 4, 20, 6))
+	expect(t, a == Nearest{P(10, 4), 0, 1})
+	expect(t, b == Nearest{P(12, 4), 0, 0})
+}
+
+func TestPolyNearestPoint(t *testing.T) {
+	small := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}
+	dualPolyTest(t, octagon, [][]Point{small}, func(t *testing.T, poly *Poly) {
+		expect(t, poly.NearestPoint(P(3, 5)) == Nearest{P(3, 5), 0, -1})
+		expect(t, poly.NearestPoint(P(5, 5.5)) == Nearest{P(5, 6), 1, 2})
+		expect(t, poly.NearestPoint(P(5, -1)) == Nearest{P(5, 0), 0, 0})
+		expect(t, poly.NearestPoint(P(12, 5)) == Nearest{P(10, 5), 0, 2})
+	})
+	expect(t, (&Poly{}).NearestPoint(P(0, 0)).Index == -1)
+}
+
+func TestClosestPair(t *testing.T) {
+	// crossing lines
+	a, b := L(P(0, 0), P(10, 10)).ClosestPairLine(L(P(0, 10), P(10, 0)))
+	expect(t, a == Nearest{P(5, 5), 0, 0} && b == Nearest{P(5, 5), 0, 0})
+	// parallel lines
+	a, b = L(P(0, 0), P(10, 0)).ClosestPairLine(L(P(2, 3), P(4, 3), P(4, 1)))
+	expect(t, a == Nearest{P(4, 0), 0, 0} && b == Nearest{P(4, 1), 0, 1})
+
+	small := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}
+	poly := NewPoly(octagon, [][]Point{small}, DefaultIndexOptions)
+	// line outside, crossing, inside, and inside the hole
+	a, b = poly.ClosestPairLine(L(P(12, 0), P(12, 10)))
+	expect(t, a == Nearest{P(10, 3), 0, 2} && b == Nearest{P(12, 3), 0, 0})
+	a, b = poly.ClosestPairLine(L(P(5, -5), P(5, 1)))
+	expect(t, a == Nearest{P(5, 0), 0, 0} && b == Nearest{P(5, 0), 0, 0})
+	a, b = poly.ClosestPairLine(L(P(5, 1), P(5, 2)))
+	expect(t, a == Nearest{P(5, 1), 0, -1} && b == Nearest{P(5, 1), 0, 0})
+	a, b = poly.ClosestPairLine(L(P(4.5, 5), P(5.5, 5)))
+	expect(t, a == Nearest{P(4, 5), 1, 3} && b == Nearest{P(4.5, 5), 0, 0})
+	b, a = L(P(4.5, 5), P(5.5, 5)).ClosestPairPoly(poly)
+	expect(t, a == Nearest{P(4, 5), 1, 3} && b == Nearest{P(4.5, 5), 0, 0})
+
+	// polygons
+	other := NewPoly(rectangle, nil, nil).Move(12, 0)
+	a, b = poly.ClosestPairPoly(other)
+	expect(t, a == Nearest{P(10, 3), 0, 2} && b == Nearest{P(12, 3), 0, 3})
+	a, b = poly.ClosestPairPoly(&Poly{Exterior: R(2, 2, 3, 3)})
+	expect(t, a == Nearest{P(2, 2), 0, -1} && b == Nearest{P(2, 2), 0, 0})
+	a, b = (&Poly{Exterior: R(-1, -1, 11, 11)}).ClosestPairPoly(poly)
+	expect(t, a == Nearest{P(3, 0), 0, -1} && b == Nearest{P(3, 0), 0, 0})
+	a, b = poly.ClosestPairPoly(&Poly{})
+	expect(t, a.Index == -1 && b.Index == -1)
+}
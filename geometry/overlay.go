@@ -0,0 +1,446 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// OverlayOp is a polygon boolean operation
+type OverlayOp int
+
+const (
+	// OverlayUnion keeps the area that is in either set of polygons
+	OverlayUnion OverlayOp = iota
+	// OverlayIntersection keeps the area that is in both sets of polygons
+	OverlayIntersection
+	// OverlayDifference keeps the area of the first set of polygons that is
+	// not in the second set
+	OverlayDifference
+	// OverlaySymmetricDifference keeps the area that is in only one of the
+	// sets of polygons
+	OverlaySymmetricDifference
+)
+
+// Union returns the area that is in either polygon.
+func (poly *Poly) Union(other *Poly) []*Poly {
+	return Overlay([]*Poly{poly}, []*Poly{other}, OverlayUnion)
+}
+
+// Intersection returns the area that is in both polygons.
+func (poly *Poly) Intersection(other *Poly) []*Poly {
+	return Overlay([]*Poly{poly}, []*Poly{other}, OverlayIntersection)
+}
+
+// Difference returns the area of the polygon that is not in the other
+// polygon.
+func (poly *Poly) Difference(other *Poly) []*Poly {
+	return Overlay([]*Poly{poly}, []*Poly{other}, OverlayDifference)
+}
+
+// SymmetricDifference returns the area that is in only one of the polygons.
+func (poly *Poly) SymmetricDifference(other *Poly) []*Poly {
+	return Overlay([]*Poly{poly}, []*Poly{other}, OverlaySymmetricDifference)
+}
+
+// Overlay performs a boolean operation on two sets of polygons, such as the
+// polygons of two MultiPolygons. The polygons in each set should not overlap
+// each other. The exterior of the returned polygons are counter-clockwise and
+// the holes are clockwise.
+func Overlay(a, b []*Poly, op OverlayOp) []*Poly {
+	return overlay(a, b, op, 0)
+}
+
+// overlay performs a boolean operation, where points that are within the
+// tolerance of a segment are treated as being on the segment. The tolerance
+// is never less than the rounding error of the coordinates, and it's raised
+// when the edges can't be joined into closed rings.
+func overlay(a, b []*Poly, op OverlayOp, tolerance float64) []*Poly {
+	a, b = nonEmptyPolys(a), nonEmptyPolys(b)
+	if op == OverlaySymmetricDifference {
+		// the two differences don't share any area, so they aren't merged
+		return append(overlay(a, b, OverlayDifference, tolerance),
+			overlay(b, a, OverlayDifference, tolerance)...)
+	}
+	scale := overlayScale(a, b)
+	tolerance = math.Max(tolerance, scale*1e-12)
+	for {
+		rings, closed := overlayRings(overlayEdges(a, b, op, tolerance))
+		if closed || tolerance >= scale*1e-6 {
+			return overlayPolys(rings)
+		}
+		tolerance *= 1000
+	}
+}
+
+// overlayScale returns the largest absolute coordinate of the polygons.
+func overlayScale(a, b []*Poly) float64 {
+	scale := 1.0
+	for _, polys := range [2][]*Poly{a, b} {
+		for _, poly := range polys {
+			rect := poly.Rect()
+			scale = math.Max(scale, math.Max(
+				math.Max(math.Abs(rect.Min.X), math.Abs(rect.Min.Y)),
+				math.Max(math.Abs(rect.Max.X), math.Abs(rect.Max.Y))))
+		}
+	}
+	return scale
+}
+
+// overlayEdges returns the noded edges of both sets of polygons that bound
+// the result of the operation.
+func overlayEdges(a, b []*Poly, op OverlayOp, tolerance float64) [][2]Point {
+	edgesA, edgesB := overlayNode(a, b, tolerance)
+	var edges [][2]Point
+	shared := make(map[[2]Point]bool, len(edgesB))
+	for _, edge := range edgesB {
+		shared[edge] = true
+	}
+	for _, edge := range edgesA {
+		switch {
+		case shared[edge]:
+			// the same edge in the same direction
+			if op != OverlayDifference {
+				edges = append(edges, edge)
+			}
+		case shared[[2]Point{edge[1], edge[0]}]:
+			// the same edge in the opposite direction
+			if op == OverlayDifference {
+				edges = append(edges, edge)
+			}
+		default:
+			inside := overlayInside(b, edge)
+			if inside == (op == OverlayIntersection) {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	shared = make(map[[2]Point]bool, len(edgesA))
+	for _, edge := range edgesA {
+		shared[edge] = true
+	}
+	for _, edge := range edgesB {
+		reversed := [2]Point{edge[1], edge[0]}
+		if shared[edge] || shared[reversed] {
+			continue
+		}
+		inside := overlayInside(a, edge)
+		switch op {
+		case OverlayUnion:
+			if !inside {
+				edges = append(edges, edge)
+			}
+		case OverlayIntersection:
+			if inside {
+				edges = append(edges, edge)
+			}
+		case OverlayDifference:
+			if inside {
+				edges = append(edges, reversed)
+			}
+		}
+	}
+	return edges
+}
+
+func nonEmptyPolys(polys []*Poly) []*Poly {
+	var result []*Poly
+	for _, poly := range polys {
+		if poly != nil && !poly.Empty() {
+			result = append(result, poly)
+		}
+	}
+	return result
+}
+
+// overlayInside returns true when the middle of an edge is inside of the
+// polygons.
+func overlayInside(polys []*Poly, edge [2]Point) bool {
+	mid := Point{X: (edge[0].X + edge[1].X) / 2, Y: (edge[0].Y + edge[1].Y) / 2}
+	for _, poly := range polys {
+		if polyLocate(poly, mid) == locInterior {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaySegment is a polygon ring segment that is split at the points where
+// it meets the segments of the other polygons.
+type overlaySegment struct {
+	seg    Segment
+	splits []Point
+}
+
+// overlayRing is a polygon ring that is wound with the polygon interior on
+// the left side of its segments.
+type overlayRing struct {
+	ring     Ring
+	reversed bool
+	segs     []overlaySegment
+}
+
+func newOverlayRings(polys []*Poly) []*overlayRing {
+	var rings []*overlayRing
+	for _, poly := range polys {
+		for i, ring := range polyRings(poly) {
+			n := ring.NumSegments()
+			or := &overlayRing{
+				ring:     ring,
+				reversed: ring.Clockwise() != (i > 0),
+				segs:     make([]overlaySegment, n),
+			}
+			for j := 0; j < n; j++ {
+				seg := ring.SegmentAt(j)
+				if or.reversed {
+					seg = Segment{A: seg.B, B: seg.A}
+				}
+				or.segs[j].seg = seg
+			}
+			rings = append(rings, or)
+		}
+	}
+	return rings
+}
+
+// overlayOn returns true when a point is within the tolerance of a segment.
+func overlayOn(seg Segment, p Point, tolerance float64) bool {
+	return distSq(seg.NearestPoint(p), p) <= tolerance*tolerance
+}
+
+// overlaySnap returns a split point of either segment that is within the
+// tolerance of a crossing, so that crossings that are within the rounding
+// error of each other meet at one point, or else the crossing itself.
+func overlaySnap(p Point, sa, sb *overlaySegment, tolerance float64) Point {
+	for _, splits := range [2][]Point{sa.splits, sb.splits} {
+		for _, q := range splits {
+			if distSq(p, q) <= tolerance*tolerance {
+				return q
+			}
+		}
+	}
+	return p
+}
+
+// overlayNode splits the segments of both sets of polygons where they meet
+// each other, and returns the resulting edges. Both sets share the exact same
+// points at the splits, so that an edge that is in both can be matched. A
+// vertex within the tolerance of a segment splits it at the vertex, and a
+// crossing splits both segments at the same snapped point.
+func overlayNode(a, b []*Poly, tolerance float64) (edgesA, edgesB [][2]Point) {
+	ringsA, ringsB := newOverlayRings(a), newOverlayRings(b)
+	for _, ra := range ringsA {
+		for i := range ra.segs {
+			sa := &ra.segs[i]
+			rect := sa.seg.Rect()
+			rect.Min.X -= tolerance
+			rect.Min.Y -= tolerance
+			rect.Max.X += tolerance
+			rect.Max.Y += tolerance
+			for _, rb := range ringsB {
+				rb.ring.Search(rect, func(_ Segment, idx int) bool {
+					sb := &rb.segs[idx]
+					var touched bool
+					for _, p := range [2]Point{sb.seg.A, sb.seg.B} {
+						if overlayOn(sa.seg, p, tolerance) {
+							sa.splits = append(sa.splits, p)
+							touched = true
+						}
+					}
+					for _, p := range [2]Point{sa.seg.A, sa.seg.B} {
+						if overlayOn(sb.seg, p, tolerance) {
+							sb.splits = append(sb.splits, p)
+							touched = true
+						}
+					}
+					if !touched && sa.seg.IntersectsSegment(sb.seg) {
+						p := overlaySnap(sa.seg.intersectionPoint(sb.seg),
+							sa, sb, tolerance)
+						sa.splits = append(sa.splits, p)
+						sb.splits = append(sb.splits, p)
+					}
+					return true
+				})
+			}
+		}
+	}
+	return overlayRingEdges(ringsA), overlayRingEdges(ringsB)
+}
+
+// overlayRingEdges returns the split edges of the rings. The segments of a
+// reversed ring are stored in the original order, so they are read backwards.
+func overlayRingEdges(rings []*overlayRing) [][2]Point {
+	var edges [][2]Point
+	for _, ring := range rings {
+		n := len(ring.segs)
+		for i := 0; i < n; i++ {
+			s := ring.segs[i]
+			if ring.reversed {
+				s = ring.segs[n-1-i]
+			}
+			edges = appendSplitEdges(edges, s)
+		}
+	}
+	return edges
+}
+
+// appendSplitEdges appends the parts of a segment between its split points.
+func appendSplitEdges(edges [][2]Point, s overlaySegment) [][2]Point {
+	a, b := s.seg.A, s.seg.B
+	if a == b {
+		return edges
+	}
+	sort.Slice(s.splits, func(i, j int) bool {
+		return segmentParam(s.seg, s.splits[i]) < segmentParam(s.seg, s.splits[j])
+	})
+	prev := a
+	for _, p := range s.splits {
+		if p == prev || p == a || p == b {
+			continue
+		}
+		edges = append(edges, [2]Point{prev, p})
+		prev = p
+	}
+	return append(edges, [2]Point{prev, b})
+}
+
+// overlayRings joins edges into closed rings. Where more than one edge leaves
+// a point, the one that makes the sharpest left turn is used, which keeps
+// polygons that touch at a point apart. It returns false when some edges
+// can't be closed into a ring, which are left out.
+func overlayRings(edges [][2]Point) (rings [][]Point, closed bool) {
+	outgoing := make(map[Point][]int)
+	for i, edge := range edges {
+		outgoing[edge[0]] = append(outgoing[edge[0]], i)
+	}
+	used := make([]bool, len(edges))
+	closed = true
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		start := edges[i][0]
+		ring := []Point{start, edges[i][1]}
+		prev, cur := start, edges[i][1]
+		for cur != start {
+			next := -1
+			best := math.Inf(+1)
+			back := math.Atan2(prev.Y-cur.Y, prev.X-cur.X)
+			for _, j := range outgoing[cur] {
+				if used[j] {
+					continue
+				}
+				to := edges[j][1]
+				angle := math.Mod(back-math.Atan2(to.Y-cur.Y, to.X-cur.X),
+					2*math.Pi)
+				if angle <= 0 {
+					angle += 2 * math.Pi
+				}
+				if angle < best {
+					next, best = j, angle
+				}
+			}
+			if next == -1 {
+				ring, closed = nil, false
+				break
+			}
+			used[next] = true
+			prev, cur = cur, edges[next][1]
+			ring = append(ring, cur)
+		}
+		if ring = removeCollinear(ring); len(ring) >= 4 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings, closed
+}
+
+// removeCollinear removes the points of a closed ring that are on a straight
+// line between their neighbors.
+func removeCollinear(ring []Point) []Point {
+	if len(ring) < 4 {
+		return nil
+	}
+	pts := ring[:len(ring)-1]
+	var result []Point
+	n := len(pts)
+	for i := 0; i < n; i++ {
+		prev, p, next := pts[(i+n-1)%n], pts[i], pts[(i+1)%n]
+		cross := (p.X-prev.X)*(next.Y-prev.Y) - (p.Y-prev.Y)*(next.X-prev.X)
+		dot := (p.X-prev.X)*(next.X-p.X) + (p.Y-prev.Y)*(next.Y-p.Y)
+		if cross == 0 && dot >= 0 {
+			continue
+		}
+		result = append(result, p)
+	}
+	if len(result) < 3 {
+		return nil
+	}
+	return append(result, result[0])
+}
+
+// overlayPolys turns counter-clockwise rings into polygon exteriors, and
+// assigns each clockwise ring to the smallest exterior that contains it.
+func overlayPolys(rings [][]Point) []*Poly {
+	type shell struct {
+		ring  Ring
+		area  float64
+		holes [][]Point
+	}
+	var shells []*shell
+	var holes [][]Point
+	for _, pts := range rings {
+		ring := newRing(pts, DefaultIndexOptions)
+		if ring.Clockwise() {
+			holes = append(holes, pts)
+		} else {
+			shells = append(shells, &shell{ring: ring, area: seriesArea(ring)})
+		}
+	}
+	for _, hole := range holes {
+		var owner *shell
+		for _, s := range shells {
+			if (owner == nil || s.area < owner.area) && ringInside(s.ring, hole) {
+				owner = s
+			}
+		}
+		if owner != nil {
+			owner.holes = append(owner.holes, hole)
+		}
+	}
+	polys := make([]*Poly, len(shells))
+	for i, s := range shells {
+		polys[i] = &Poly{Exterior: s.ring}
+		for _, hole := range s.holes {
+			polys[i].Holes = append(polys[i].Holes,
+				newRing(hole, DefaultIndexOptions))
+		}
+	}
+	return polys
+}
+
+// ringInside returns true if the points are inside of the ring, which they
+// may touch. The middles of the edges are tried first, because the points
+// are often on the ring, or level with its points, where a raycast is the
+// least reliable.
+func ringInside(ring Ring, pts []Point) bool {
+	for i := 1; i < len(pts); i++ {
+		mid := Point{X: (pts[i-1].X + pts[i].X) / 2,
+			Y: (pts[i-1].Y + pts[i].Y) / 2}
+		res := ringContainsPoint(ring, mid, false)
+		if res.idx < 0 {
+			return res.hit
+		}
+	}
+	for _, p := range pts {
+		res := ringContainsPoint(ring, p, false)
+		if res.idx < 0 {
+			return res.hit
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func overlayArea(polys []*Poly) float64 {
+	var area float64
+	for _, poly := range polys {
+		area += poly.Area()
+	}
+	return area
+}
+
+func expectOverlay(t *testing.T, polys []*Poly, count int, area float64) {
+	t.Helper()
+	if len(polys) != count {
+		t.Fatalf("expected %d polygons, got %d", count, len(polys))
+	}
+	if a := overlayArea(polys); math.Abs(a-area) > 1e-9 {
+		t.Fatalf("expected area %v, got %v", area, a)
+	}
+	for _, poly := range polys {
+		expect(t, !poly.Exterior.Clockwise())
+		for _, hole := range poly.Holes {
+			expect(t, hole.Clockwise())
+		}
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	a := NewPoly(rectangle, nil, DefaultIndexOptions)
+	b := &Poly{Exterior: R(5, 5, 15, 15)}
+	expectOverlay(t, a.Union(b), 1, 175)
+	expectOverlay(t, a.Intersection(b), 1, 25)
+	expectOverlay(t, a.Difference(b), 1, 75)
+	expectOverlay(t, b.Difference(a), 1, 75)
+	expectOverlay(t, a.SymmetricDifference(b), 2, 150)
+	expect(t, a.Intersection(b)[0].Exterior.NumPoints() == 5)
+	expect(t, a.Intersection(b)[0].Rect() == R(5, 5, 10, 10))
+	union := a.Union(b)[0]
+	expect(t, union.Exterior.NumPoints() == 9)
+	expect(t, union.ContainsPoint(P(12, 12)) && !union.ContainsPoint(P(12, 2)))
+
+	// the winding of the input does not matter
+	cw := NewPoly([]Point{{5, 5}, {5, 15}, {15, 15}, {15, 5}, {5, 5}}, nil, nil)
+	expectOverlay(t, a.Union(cw), 1, 175)
+	expectOverlay(t, a.Intersection(cw), 1, 25)
+
+	// disjoint
+	far := &Poly{Exterior: R(20, 20, 30, 30)}
+	expectOverlay(t, a.Union(far), 2, 200)
+	expectOverlay(t, a.Intersection(far), 0, 0)
+	expectOverlay(t, a.Difference(far), 1, 100)
+
+	// the same polygon
+	expectOverlay(t, a.Union(a), 1, 100)
+	expectOverlay(t, a.Intersection(a), 1, 100)
+	expectOverlay(t, a.Difference(a), 0, 0)
+	expectOverlay(t, a.SymmetricDifference(a), 0, 0)
+
+	// adjacent polygons are merged into one
+	adjacent := &Poly{Exterior: R(10, 0, 20, 10)}
+	merged := a.Union(adjacent)
+	expectOverlay(t, merged, 1, 200)
+	expect(t, merged[0].Exterior.NumPoints() == 5)
+	expect(t, merged[0].Rect() == R(0, 0, 20, 10))
+	expectOverlay(t, a.Intersection(adjacent), 0, 0)
+	expectOverlay(t, a.Difference(adjacent), 1, 100)
+	// partly adjacent
+	expectOverlay(t, a.Union(&Poly{Exterior: R(10, 5, 20, 15)}), 1, 200)
+
+	// touching at a corner stays apart
+	expectOverlay(t, a.Union(&Poly{Exterior: R(10, 10, 20, 20)}), 2, 200)
+
+	// a polygon inside of another makes a hole
+	inner := &Poly{Exterior: R(2, 2, 8, 8)}
+	holed := a.Difference(inner)
+	expectOverlay(t, holed, 1, 64)
+	expect(t, len(holed[0].Holes) == 1)
+	expect(t, !holed[0].ContainsPoint(P(5, 5)) && holed[0].ContainsPoint(P(1, 1)))
+	expectOverlay(t, a.Union(inner), 1, 100)
+	expectOverlay(t, a.Intersection(inner), 1, 36)
+	expectOverlay(t, inner.Difference(a), 0, 0)
+	// filling the hole
+	expectOverlay(t, holed[0].Union(inner), 1, 100)
+	expect(t, len(holed[0].Union(inner)[0].Holes) == 0)
+	expectOverlay(t, holed[0].Intersection(inner), 0, 0)
+	// a polygon that is in the hole and outside
+	expectOverlay(t, holed[0].Intersection(&Poly{Exterior: R(1, 4, 15, 6)}),
+		2, 6)
+
+	// an inner polygon sharing an edge
+	expectOverlay(t, a.Difference(&Poly{Exterior: R(0, 0, 5, 10)}), 1, 50)
+
+	// a crossing shape makes two pieces
+	bar := &Poly{Exterior: R(-5, 4, 15, 6)}
+	expectOverlay(t, a.Difference(bar), 2, 80)
+	expectOverlay(t, a.Union(bar), 1, 120)
+
+	// concave shapes
+	c1 := NewPoly(concave1, nil, DefaultIndexOptions)
+	c4 := NewPoly(concave4, nil, DefaultIndexOptions)
+	expectOverlay(t, c1.Intersection(c4), 1, 50)
+	expectOverlay(t, c1.Union(c4), 1, 100)
+
+	// triangles
+	tri := NewPoly(triangle, nil, DefaultIndexOptions)
+	expectOverlay(t, tri.Intersection(&Poly{Exterior: R(0, 0, 10, 5)}), 1, 37.5)
+
+	// a vertex that is on an edge, give or take a rounding error
+	p1 := NewPoly([]Point{{2.77, 0}, {0.99, 0.57}, {0.78, 1.35}, {0, 1.51},
+		{-1.07, 1.86}, {-2.53, 1.46}, {-1.81, 0}, {-1.58, -0.91},
+		{-0.68, -1.18}, {0, -2.98}, {1.12, -1.93}, {1.49, -0.86}, {2.77, 0}},
+		nil, nil)
+	p2 := NewPoly([]Point{{4.57, 0.07}, {3.82, 0.61}, {3.37, 0.93},
+		{2.91, 1.06}, {2.17, 2.33}, {0.92, 2.28}, {0.24, 1.25}, {0.58, 0.07},
+		{0.24, -1.11}, {2.04, -0.75}, {2.03, -2.82}, {3.33, -2.77},
+		{4.32, -1.98}, {3.79, -0.47}, {4.57, 0.07}}, nil, nil)
+	inter := overlayArea(p1.Intersection(p2))
+	expect(t, inter > 0)
+	expectOverlay(t, p1.Union(p2), 1, p1.Area()+p2.Area()-inter)
+	expectOverlay(t, p1.Difference(p2), 1, p1.Area()-inter)
+
+	// edges that can't be closed into a ring are reported
+	_, closed := overlayRings([][2]Point{{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}})
+	expect(t, !closed)
+
+	// empty polygons
+	expectOverlay(t, a.Union(NewPoly(nil, nil, nil)), 1, 100)
+	expectOverlay(t, Overlay(nil, []*Poly{a}, OverlayDifference), 0, 0)
+	expectOverlay(t, Overlay(nil, []*Poly{a, far}, OverlayUnion), 2, 200)
+}
+
+func TestOverlayStates(t *testing.T) {
+	texas := NewPoly(tx, nil, DefaultIndexOptions)
+	rect := texas.Rect()
+	half := &Poly{Exterior: R(rect.Min.X, rect.Min.Y,
+		(rect.Min.X+rect.Max.X)/2, rect.Max.Y)}
+	west, east := texas.Intersection(half), texas.Difference(half)
+	expect(t, len(west) > 0 && len(east) > 0)
+	total := texas.Area()
+	expect(t, math.Abs(overlayArea(west)+overlayArea(east)-total) < 1e-9)
+	union := Overlay(west, east, OverlayUnion)
+	expectOverlay(t, union, 1, total)
+}
+
+// randomStar returns a random star shaped polygon, whose points are on a
+// grid, so that the edges of two polygons often meet at a point.
+func randomStar(r *rand.Rand) *Poly {
+	return randomStarGrid(r, 1)
+}
+
+// randomStarGrid returns a random star shaped polygon, whose points are
+// rounded to a grid with the spacing, unless it's zero.
+func randomStarGrid(r *rand.Rand, spacing float64) *Poly {
+	cx, cy := float64(r.Intn(10)), float64(r.Intn(10))
+	n := 3 + r.Intn(10)
+	points := make([]Point, 0, n+1)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * (float64(i) + r.Float64()*0.5) / float64(n)
+		radius := 1 + r.Float64()*9
+		p := Point{cx + math.Cos(angle)*radius, cy + math.Sin(angle)*radius}
+		if spacing != 0 {
+			p.X = math.Round(p.X/spacing) * spacing
+			p.Y = math.Round(p.Y/spacing) * spacing
+		}
+		points = append(points, p)
+	}
+	points = append(points, points[0])
+	return NewPoly(points, nil, nil)
+}
+
+func TestOverlayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		a, b := randomStar(r), randomStar(r)
+		if a.Exterior.Empty() || b.Exterior.Empty() || a.Area() == 0 ||
+			b.Area() == 0 {
+			continue
+		}
+		union := overlayArea(a.Union(b))
+		inter := overlayArea(a.Intersection(b))
+		diffA := overlayArea(a.Difference(b))
+		diffB := overlayArea(b.Difference(a))
+		sym := overlayArea(a.SymmetricDifference(b))
+		expect(t, math.Abs(union-(a.Area()+b.Area()-inter)) < 1e-6)
+		expect(t, math.Abs(diffA-(a.Area()-inter)) < 1e-6)
+		expect(t, math.Abs(diffB-(b.Area()-inter)) < 1e-6)
+		expect(t, math.Abs(sym-(diffA+diffB)) < 1e-6)
+	}
+}
+
+func TestOverlayRandomAreas(t *testing.T) {
+	// Points on a fine grid land on the other polygon's edges, give or take
+	// a rounding error, which must still be noded the same for both.
+	r := rand.New(rand.NewSource(5))
+	for _, spacing := range []float64{0.1, 0.3, 0.01, 0} {
+		for i := 0; i < 3000; i++ {
+			a, b := randomStarGrid(r, spacing), randomStarGrid(r, spacing)
+			if a.Area() == 0 || b.Area() == 0 {
+				continue
+			}
+			union := overlayArea(a.Union(b))
+			inter := overlayArea(a.Intersection(b))
+			diff := overlayArea(a.Difference(b))
+			tol := 1e-9 * (a.Area() + b.Area())
+			if math.Abs(union-(a.Area()+b.Area()-inter)) > tol ||
+				math.Abs(diff-(a.Area()-inter)) > tol {
+				t.Fatalf("spacing %v, pair %d: union %v, intersection %v, "+
+					"difference %v, areas %v and %v", spacing, i, union,
+					inter, diff, a.Area(), b.Area())
+			}
+		}
+	}
+}
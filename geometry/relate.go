@@ -0,0 +1,360 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// locations in the DE-9IM
+const (
+	locInterior = 0
+	locBoundary = 1
+	locExterior = 2
+)
+
+// Composite is a set of points, lines and polygons that are treated as a
+// single geometry, such as a MultiPolygon or a GeometryCollection.
+type Composite struct {
+	Points []Point
+	Lines  []*Line
+	Polys  []*Poly
+}
+
+// Dimension returns the topological dimension of the composite, which is 2
+// for polygons, 1 for lines, 0 for points, and -1 when empty.
+func (c *Composite) Dimension() int {
+	switch {
+	case len(c.Polys) > 0:
+		return 2
+	case len(c.Lines) > 0:
+		return 1
+	case len(c.Points) > 0:
+		return 0
+	}
+	return -1
+}
+
+// polyLocate returns the location of a point relative to a polygon.
+func polyLocate(poly *Poly, point Point) int {
+	res := ringContainsPoint(poly.Exterior, point, false)
+	if res.idx >= 0 {
+		return locBoundary
+	}
+	if !res.hit {
+		return locExterior
+	}
+	for _, hole := range poly.Holes {
+		res := ringContainsPoint(hole, point, false)
+		if res.idx >= 0 {
+			return locBoundary
+		}
+		if res.hit {
+			return locExterior
+		}
+	}
+	return locInterior
+}
+
+// locateArea returns the location of a point relative to the polygons only.
+func (c *Composite) locateArea(point Point) int {
+	loc := locExterior
+	for _, poly := range c.Polys {
+		switch polyLocate(poly, point) {
+		case locInterior:
+			return locInterior
+		case locBoundary:
+			loc = locBoundary
+		}
+	}
+	return loc
+}
+
+func lineClosed(line *Line) bool {
+	return line.PointAt(0) == line.PointAt(line.NumPoints()-1)
+}
+
+// locate returns the location of a point relative to the composite. The
+// boundary of the lines follows the mod-2 rule, where an endpoint that is
+// shared by an even number of lines is in the interior.
+func (c *Composite) locate(point Point) int {
+	loc := c.locateArea(point)
+	if loc == locInterior {
+		return locInterior
+	}
+	var endpoints int
+	for _, line := range c.Lines {
+		if !lineClosed(line) &&
+			(point == line.PointAt(0) || point == line.PointAt(line.NumPoints()-1)) {
+			endpoints++
+		} else if line.IntersectsPoint(point) {
+			return locInterior
+		}
+	}
+	if endpoints%2 == 1 {
+		return locBoundary
+	}
+	if endpoints > 0 || loc == locBoundary {
+		if loc == locBoundary {
+			return locBoundary
+		}
+		return locInterior
+	}
+	for _, p := range c.Points {
+		if p == point {
+			return locInterior
+		}
+	}
+	return locExterior
+}
+
+// relateEdge is a line or polygon ring of a composite.
+type relateEdge struct {
+	series Series
+	ring   bool // the edge is a polygon ring
+	// for rings, the polygon interior is on the left side of the segments
+	interiorLeft bool
+}
+
+func (c *Composite) edges() []relateEdge {
+	var edges []relateEdge
+	for _, line := range c.Lines {
+		edges = append(edges, relateEdge{series: line})
+	}
+	for _, poly := range c.Polys {
+		for i, ring := range polyRings(poly) {
+			edges = append(edges, relateEdge{
+				series:       ring,
+				ring:         true,
+				interiorLeft: ring.Clockwise() == (i > 0),
+			})
+		}
+	}
+	return edges
+}
+
+// location returns the location of the interior of the segments of the edge
+func (edge relateEdge) location() int {
+	if edge.ring {
+		return locBoundary
+	}
+	return locInterior
+}
+
+// relateOverlap is a part of a segment that lies on a segment of another
+// edge.
+type relateOverlap struct {
+	lo, hi  float64
+	edge    relateEdge
+	sameDir bool
+}
+
+// relateMatrix is a DE-9IM matrix of dimensions, where -1 is no intersection.
+type relateMatrix [3][3]int
+
+func (m *relateMatrix) set(a, b, dim int, transpose bool) {
+	if transpose {
+		a, b = b, a
+	}
+	if dim > m[a][b] {
+		m[a][b] = dim
+	}
+}
+
+// segmentParam returns the position of a point projected onto a segment,
+// where 0 is A and 1 is B.
+func segmentParam(seg Segment, point Point) float64 {
+	dx, dy := seg.B.X-seg.A.X, seg.B.Y-seg.A.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return 0
+	}
+	t := ((point.X-seg.A.X)*dx + (point.Y-seg.A.Y)*dy) / lenSq
+	return math.Max(0, math.Min(1, t))
+}
+
+// relateOneWay adds to the matrix the locations of the points, vertices and
+// segments of a relative to b.
+func (m *relateMatrix) relateOneWay(a, b *Composite, transpose bool) {
+	for _, point := range a.Points {
+		m.set(locInterior, b.locate(point), 0, transpose)
+	}
+	bEdges := b.edges()
+	bHasArea := len(b.Polys) > 0
+	for _, edge := range a.edges() {
+		series := edge.series
+		for i := 0; i < series.NumPoints(); i++ {
+			point := series.PointAt(i)
+			m.set(a.locate(point), b.locate(point), 0, transpose)
+		}
+		for i := 0; i < series.NumSegments(); i++ {
+			seg := series.SegmentAt(i)
+			params := []float64{0, 1}
+			var overlaps []relateOverlap
+			for _, bEdge := range bEdges {
+				bEdge.series.Search(seg.Rect(), func(other Segment, _ int) bool {
+					if !seg.IntersectsSegment(other) {
+						return true
+					}
+					if seg.CollinearPoint(other.A) && seg.CollinearPoint(other.B) {
+						lo, hi := segmentParam(seg, other.A), segmentParam(seg, other.B)
+						if lo > hi {
+							lo, hi = hi, lo
+						}
+						params = append(params, lo, hi)
+						if hi > lo {
+							dx, dy := seg.B.X-seg.A.X, seg.B.Y-seg.A.Y
+							ox, oy := other.B.X-other.A.X, other.B.Y-other.A.Y
+							overlaps = append(overlaps, relateOverlap{
+								lo: lo, hi: hi, edge: bEdge,
+								sameDir: dx*ox+dy*oy > 0,
+							})
+						}
+						return true
+					}
+					point := seg.intersectionPoint(other)
+					params = append(params, segmentParam(seg, point))
+					locA, locB := edge.location(), bEdge.location()
+					if point == seg.A || point == seg.B {
+						locA = a.locate(point)
+					}
+					if point == other.A || point == other.B {
+						locB = b.locate(point)
+					}
+					m.set(locA, locB, 0, transpose)
+					return true
+				})
+			}
+			for _, point := range b.Points {
+				if seg.Raycast(point).On {
+					params = append(params, segmentParam(seg, point))
+				}
+			}
+			sort.Float64s(params)
+			for j := 1; j < len(params); j++ {
+				lo, hi := params[j-1], params[j]
+				if hi <= lo {
+					continue
+				}
+				m.relateSubSegment(a, b, edge, seg, lo, hi, overlaps,
+					bHasArea, transpose)
+			}
+		}
+	}
+}
+
+// relateSubSegment adds the location of the part of a segment between two
+// nodes, and when the segment is a polygon ring, the locations of the areas
+// on either side of it.
+func (m *relateMatrix) relateSubSegment(a, b *Composite, edge relateEdge,
+	seg Segment, lo, hi float64, overlaps []relateOverlap, bHasArea,
+	transpose bool,
+) {
+	mid := (lo + hi) / 2
+	point := Point{
+		X: seg.A.X + (seg.B.X-seg.A.X)*mid,
+		Y: seg.A.Y + (seg.B.Y-seg.A.Y)*mid,
+	}
+	var onLine, onRing bool
+	var ring relateOverlap
+	for _, o := range overlaps {
+		if o.lo <= lo && o.hi >= hi {
+			if o.edge.ring {
+				onRing, ring = true, o
+			} else {
+				onLine = true
+			}
+		}
+	}
+	var locB int
+	switch {
+	case onRing:
+		locB = locBoundary
+	case onLine:
+		locB = locInterior
+	default:
+		locB = b.locate(point)
+	}
+	m.set(edge.location(), locB, 1, transpose)
+	if !edge.ring {
+		return
+	}
+	// the areas on either side of a polygon ring
+	var inside, outside int
+	switch {
+	case onRing:
+		bLeft := ring.edge.interiorLeft == ring.sameDir
+		if edge.interiorLeft == bLeft {
+			inside, outside = locInterior, locExterior
+		} else {
+			inside, outside = locExterior, locInterior
+		}
+	case !bHasArea:
+		inside, outside = locExterior, locExterior
+	default:
+		loc := b.locateArea(point)
+		if loc == locBoundary {
+			return
+		}
+		inside, outside = loc, loc
+	}
+	m.set(locInterior, inside, 2, transpose)
+	m.set(locExterior, outside, 2, transpose)
+}
+
+// dissolved returns the composite with its polygons unioned when any of them
+// touch, so that the edges that they share are in the interior rather than
+// on the boundary.
+func (c *Composite) dissolved() *Composite {
+	var touch bool
+	for i := 0; i < len(c.Polys) && !touch; i++ {
+		for j := i + 1; j < len(c.Polys); j++ {
+			if c.Polys[i].Rect().IntersectsRect(c.Polys[j].Rect()) {
+				touch = true
+				break
+			}
+		}
+	}
+	if !touch {
+		return c
+	}
+	polys := []*Poly{c.Polys[0]}
+	for _, poly := range c.Polys[1:] {
+		polys = Overlay(polys, []*Poly{poly}, OverlayUnion)
+	}
+	return &Composite{Points: c.Points, Lines: c.Lines, Polys: polys}
+}
+
+// Relate returns the DE-9IM intersection matrix between the composite and
+// another composite, such as "212FF1FF2". Polygons in a composite that share
+// edges are treated as one polygon.
+func (c *Composite) Relate(other *Composite) string {
+	c, other = c.dissolved(), other.dissolved()
+	var m relateMatrix
+	for i := range m {
+		m[i] = [3]int{-1, -1, -1}
+	}
+	m[locExterior][locExterior] = 2
+	if len(c.Polys) > 0 && len(other.Polys) == 0 {
+		m[locInterior][locExterior] = 2
+	}
+	if len(other.Polys) > 0 && len(c.Polys) == 0 {
+		m[locExterior][locInterior] = 2
+	}
+	m.relateOneWay(c, other, false)
+	m.relateOneWay(other, c, true)
+	matrix := make([]byte, 9)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if m[i][j] < 0 {
+				matrix[i*3+j] = 'F'
+			} else {
+				matrix[i*3+j] = byte('0' + m[i][j])
+			}
+		}
+	}
+	return string(matrix)
+}
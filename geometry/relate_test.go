@@ -0,0 +1,135 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import "testing"
+
+func relatePoly(exterior []Point, holes ...[]Point) *Composite {
+	return &Composite{Polys: []*Poly{NewPoly(exterior, holes, DefaultIndexOptions)}}
+}
+
+func relateLine(points ...Point) *Composite {
+	return &Composite{Lines: []*Line{L(points...)}}
+}
+
+func relateRect(minX, minY, maxX, maxY float64) *Composite {
+	r := R(minX, minY, maxX, maxY)
+	return &Composite{Polys: []*Poly{{Exterior: r}}}
+}
+
+func expectRelate(t *testing.T, a, b *Composite, matrix string) {
+	t.Helper()
+	if m := a.Relate(b); m != matrix {
+		t.Fatalf("expected '%s', got '%s'", matrix, m)
+	}
+	// the matrix is transposed when the arguments are swapped
+	transposed := []byte{
+		matrix[0], matrix[3], matrix[6],
+		matrix[1], matrix[4], matrix[7],
+		matrix[2], matrix[5], matrix[8],
+	}
+	if m := b.Relate(a); m != string(transposed) {
+		t.Fatalf("expected '%s', got '%s' (transposed)", transposed, m)
+	}
+}
+
+func TestRelatePolyPoly(t *testing.T) {
+	expectRelate(t, relatePoly(octagon), relatePoly(octagon), "2FFF1FFF2")
+	expectRelate(t, relateRect(0, 0, 10, 10), relatePoly(rectangle), "2FFF1FFF2")
+	// the winding does not matter
+	expectRelate(t, relatePoly(rectangle),
+		relatePoly([]Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}),
+		"2FFF1FFF2")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(20, 0, 30, 10),
+		"FF2FF1212")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(2, 2, 8, 8),
+		"212FF1FF2")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(0, 0, 5, 5),
+		"212F11FF2")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(5, 5, 15, 15),
+		"212101212")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(10, 0, 20, 10),
+		"FF2F11212")
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(10, 10, 20, 20),
+		"FF2F01212")
+	// the edges partially overlap
+	expectRelate(t, relateRect(0, 0, 10, 10), relateRect(10, 5, 20, 15),
+		"FF2F11212")
+	// the octagon is inside of the rectangle, touching its sides
+	expectRelate(t, relatePoly(rectangle), relatePoly(octagon), "212F11FF2")
+	// a polygon that fills a hole
+	hole := []Point{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}}
+	expectRelate(t, relatePoly(rectangle, hole), relatePoly(hole),
+		"FF2F112F2")
+	expectRelate(t, relatePoly(rectangle, hole), relateRect(3, 3, 7, 7),
+		"FF2FF1212")
+	expectRelate(t, relatePoly(rectangle, hole), relateRect(1, 1, 9, 9),
+		"2121F12F2")
+	expectRelate(t, relatePoly(tx), relatePoly(tx), "2FFF1FFF2")
+
+	// the edge that two polygons share is not on the boundary
+	squares := &Composite{Polys: []*Poly{{Exterior: R(0, 0, 10, 10)},
+		{Exterior: R(10, 0, 20, 10)}}}
+	expectRelate(t, squares, relateRect(0, 0, 20, 10), "2FFF1FFF2")
+	expectRelate(t, squares, relateLine(P(10, 2), P(10, 8)), "102FF1FF2")
+	// polygons that only touch at a corner are still apart
+	corners := &Composite{Polys: []*Poly{{Exterior: R(0, 0, 10, 10)},
+		{Exterior: R(10, 10, 20, 20)}}}
+	expectRelate(t, corners, relateRect(0, 0, 10, 10), "2F2F11FF2")
+}
+
+func TestRelateLine(t *testing.T) {
+	rect := relateRect(0, 0, 10, 10)
+	expectRelate(t, relateLine(P(-5, 5), P(15, 5)), rect, "101FF0212")
+	expectRelate(t, relateLine(P(2, 5), P(8, 5)), rect, "1FF0FF212")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)), rect, "F1FF0F212")
+	expectRelate(t, relateLine(P(0, 5), P(5, 5)), rect, "1FF00F212")
+	expectRelate(t, relateLine(P(10, 5), P(15, 5)), rect, "FF1F00212")
+	expectRelate(t, relateLine(P(20, 5), P(25, 5)), rect, "FF1FF0212")
+
+	expectRelate(t, relateLine(P(0, 0), P(10, 10)),
+		relateLine(P(0, 10), P(10, 0)), "0F1FF0102")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)),
+		relateLine(P(5, 0), P(15, 0)), "1010F0102")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)),
+		relateLine(P(10, 0), P(0, 0)), "1FFF0FFF2")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)),
+		relateLine(P(10, 0), P(20, 0)), "FF1F00102")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)),
+		relateLine(P(5, 0), P(5, 10)), "F01FF0102")
+	expectRelate(t, relateLine(P(0, 0), P(10, 0)),
+		relateLine(P(0, 5), P(10, 5)), "FF1FF0102")
+	expectRelate(t, relateLine(v1...), relateLine(v1...), "1FFF0FFF2")
+	expectRelate(t, relateLine(u1...), relateLine(u3...), "1010FF1F2")
+}
+
+func TestRelatePoint(t *testing.T) {
+	rect := relateRect(0, 0, 10, 10)
+	point := func(x, y float64) *Composite {
+		return &Composite{Points: []Point{P(x, y)}}
+	}
+	expectRelate(t, point(5, 5), rect, "0FFFFF212")
+	expectRelate(t, point(0, 5), rect, "F0FFFF212")
+	expectRelate(t, point(15, 5), rect, "FF0FFF212")
+	line := relateLine(P(0, 0), P(10, 0))
+	expectRelate(t, point(0, 0), line, "F0FFFF102")
+	expectRelate(t, point(5, 0), line, "0FFFFF102")
+	expectRelate(t, point(5, 5), point(5, 5), "0FFFFFFF2")
+	expectRelate(t, point(5, 5), point(6, 5), "FF0FFF0F2")
+	// a closed line has no boundary
+	ring := relateLine(P(0, 0), P(10, 0), P(10, 10), P(0, 0))
+	expectRelate(t, ring, point(0, 0), "0F1FFFFF2")
+	// the mod-2 rule joins the endpoints of two lines
+	lines := &Composite{Lines: []*Line{L(P(0, 0), P(5, 0)), L(P(5, 0), P(10, 0))}}
+	expectRelate(t, lines, point(5, 0), "0F1FF0FF2")
+}
+
+func TestRelateEmpty(t *testing.T) {
+	empty := &Composite{}
+	expect(t, empty.Dimension() == -1)
+	expectRelate(t, empty, empty, "FFFFFFFF2")
+	expectRelate(t, empty, relateRect(0, 0, 10, 10), "FFFFFF212")
+	expectRelate(t, empty, relateLine(P(0, 0), P(10, 0)), "FFFFFF102")
+}
@@ -0,0 +1,371 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"container/heap"
+	"math"
+)
+
+// SimplifyAlgorithm is the algorithm used to simplify lines and polygons.
+type SimplifyAlgorithm byte
+
+// SimplifyAlgorithm types
+const (
+	// DouglasPeucker keeps the points that are farther than the tolerance
+	// from the simplified line.
+	DouglasPeucker SimplifyAlgorithm = iota
+	// VisvalingamWhyatt removes the points that form the smallest triangles
+	// with their neighbors, while those triangles have an area that is less
+	// than the tolerance squared.
+	VisvalingamWhyatt
+)
+
+func (alg SimplifyAlgorithm) String() string {
+	switch alg {
+	default:
+		return "Unknown"
+	case DouglasPeucker:
+		return "DouglasPeucker"
+	case VisvalingamWhyatt:
+		return "VisvalingamWhyatt"
+	}
+}
+
+// SimplifyOptions are line and polygon simplification options
+type SimplifyOptions struct {
+	Algorithm SimplifyAlgorithm
+	// PreserveTopology keeps the rings of polygons valid. Rings do not
+	// collapse, do not cross themselves or each other, and holes stay inside
+	// of their exteriors. Lines do not gain self-intersections. A ring or
+	// line that cannot be simplified this way is simplified with a smaller
+	// tolerance, or not at all.
+	PreserveTopology bool
+	// IndexOptions for the simplified lines and polygons
+	IndexOptions *IndexOptions
+}
+
+// DefaultSimplifyOptions uses Douglas–Peucker without preserving topology.
+var DefaultSimplifyOptions = &SimplifyOptions{
+	Algorithm:        DouglasPeucker,
+	PreserveTopology: false,
+	IndexOptions:     DefaultIndexOptions,
+}
+
+// simplifyRetries is the number of times that the tolerance is halved when a
+// topology preserving simplification is invalid.
+const simplifyRetries = 8
+
+func seriesPoints(series Series) []Point {
+	points := make([]Point, series.NumPoints())
+	for i := range points {
+		points[i] = series.PointAt(i)
+	}
+	return points
+}
+
+// ringPoints returns the points of a ring, where the last point is the same
+// as the first.
+func ringPoints(ring Ring) []Point {
+	points := seriesPoints(ring)
+	if len(points) > 0 && points[0] != points[len(points)-1] {
+		points = append(points, points[0])
+	}
+	return points
+}
+
+// douglasPeucker returns the points of a series simplified with the
+// Douglas–Peucker algorithm. The first and last points are always kept. A
+// closed series, where the first and last points are the same, is split at
+// the point that is farthest from the first.
+func douglasPeucker(points []Point, tolerance float64) []Point {
+	n := len(points)
+	if n < 3 {
+		return points
+	}
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+	tolSq := tolerance * tolerance
+	stack := [][2]int{{0, n - 1}}
+	for len(stack) > 0 {
+		i, j := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+		seg := Segment{A: points[i], B: points[j]}
+		index, best := -1, -1.0
+		for k := i + 1; k < j; k++ {
+			if d := distSq(seg.NearestPoint(points[k]), points[k]); d > best {
+				index, best = k, d
+			}
+		}
+		if index != -1 && (best > tolSq || points[i] == points[j]) {
+			keep[index] = true
+			stack = append(stack, [2]int{i, index}, [2]int{index, j})
+		}
+	}
+	var simplified []Point
+	for i, point := range points {
+		if keep[i] {
+			simplified = append(simplified, point)
+		}
+	}
+	return simplified
+}
+
+// vwPoint is a point in the Visvalingam–Whyatt queue
+type vwPoint struct {
+	index int
+	area  float64
+}
+
+type vwQueue []vwPoint
+
+func (q vwQueue) Len() int            { return len(q) }
+func (q vwQueue) Less(i, j int) bool  { return q[i].area < q[j].area }
+func (q vwQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *vwQueue) Push(x interface{}) { *q = append(*q, x.(vwPoint)) }
+func (q *vwQueue) Pop() interface{} {
+	old := *q
+	point := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return point
+}
+
+func triangleArea(a, b, c Point) float64 {
+	return math.Abs((b.X-a.X)*(c.Y-a.Y)-(c.X-a.X)*(b.Y-a.Y)) / 2
+}
+
+// visvalingamWhyatt returns the points of a series simplified with the
+// Visvalingam–Whyatt algorithm. The first and last points are always kept.
+func visvalingamWhyatt(points []Point, tolerance float64) []Point {
+	n := len(points)
+	if n < 3 {
+		return points
+	}
+	threshold := tolerance * tolerance
+	prev, next := make([]int, n), make([]int, n)
+	areas := make([]float64, n)
+	var queue vwQueue
+	for i := 0; i < n; i++ {
+		prev[i], next[i] = i-1, i+1
+		if i > 0 && i < n-1 {
+			areas[i] = triangleArea(points[i-1], points[i], points[i+1])
+			queue = append(queue, vwPoint{i, areas[i]})
+		}
+	}
+	heap.Init(&queue)
+	removed := make([]bool, n)
+	count := n
+	for queue.Len() > 0 {
+		point := heap.Pop(&queue).(vwPoint)
+		if removed[point.index] || point.area != areas[point.index] {
+			// stale
+			continue
+		}
+		if point.area >= threshold || count <= 3 {
+			break
+		}
+		i := point.index
+		removed[i] = true
+		count--
+		p, q := prev[i], next[i]
+		next[p], prev[q] = q, p
+		// neighbors never have a smaller area than a removed point
+		for _, j := range [2]int{p, q} {
+			if j > 0 && j < n-1 {
+				area := triangleArea(points[prev[j]], points[j], points[next[j]])
+				areas[j] = math.Max(area, point.area)
+				heap.Push(&queue, vwPoint{j, areas[j]})
+			}
+		}
+	}
+	var simplified []Point
+	for i := 0; i != n; i = next[i] {
+		simplified = append(simplified, points[i])
+	}
+	return simplified
+}
+
+func simplifyPoints(points []Point, tolerance float64, opts *SimplifyOptions,
+) []Point {
+	if opts.Algorithm == VisvalingamWhyatt {
+		return visvalingamWhyatt(points, tolerance)
+	}
+	return douglasPeucker(points, tolerance)
+}
+
+// simplifyValid simplifies points, halving the tolerance until the result is
+// valid. The original points are used when no simplification is valid.
+func simplifyValid(points []Point, tolerance float64, opts *SimplifyOptions,
+	valid func(simplified []Point) bool,
+) []Point {
+	for i := 0; i < simplifyRetries; i++ {
+		simplified := simplifyPoints(points, tolerance, opts)
+		if len(simplified) == len(points) || valid(simplified) {
+			return simplified
+		}
+		tolerance /= 2
+	}
+	return points
+}
+
+// seriesSelfIntersects returns true when any two segments of a series
+// intersect anywhere other than at the point that joins neighbors.
+func seriesSelfIntersects(series Series, closed bool) bool {
+	n := series.NumSegments()
+	var found bool
+	for i := 0; i < n && !found; i++ {
+		seg := series.SegmentAt(i)
+		series.Search(seg.Rect(), func(other Segment, j int) bool {
+			switch {
+			case j <= i:
+			case j == i+1:
+				found = seg.Raycast(other.B).On || other.Raycast(seg.A).On
+			case closed && i == 0 && j == n-1:
+				found = seg.Raycast(other.A).On || other.Raycast(seg.B).On
+			default:
+				found = seg.IntersectsSegment(other)
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// seriesIntersectsSeries returns true when any segments of two series
+// intersect.
+func seriesIntersectsSeries(a, b Series) bool {
+	var found bool
+	for i := 0; i < a.NumSegments() && !found; i++ {
+		seg := a.SegmentAt(i)
+		b.Search(seg.Rect(), func(other Segment, _ int) bool {
+			found = seg.IntersectsSegment(other)
+			return !found
+		})
+	}
+	return found
+}
+
+// Simplify returns a simplified line, where the tolerance is in coordinate
+// units. A nil opts uses the DefaultSimplifyOptions.
+func (line *Line) Simplify(tolerance float64, opts *SimplifyOptions) *Line {
+	if opts == nil {
+		opts = DefaultSimplifyOptions
+	}
+	if line == nil {
+		return nil
+	}
+	points := seriesPoints(line)
+	var simplified []Point
+	if opts.PreserveTopology && !seriesSelfIntersects(line, false) {
+		simplified = simplifyValid(points, tolerance, opts,
+			func(simplified []Point) bool {
+				return !seriesSelfIntersects(
+					NewLine(simplified, opts.IndexOptions), false)
+			})
+	} else {
+		simplified = simplifyPoints(points, tolerance, opts)
+	}
+	return NewLine(simplified, opts.IndexOptions)
+}
+
+// Simplify returns a simplified polygon, where the tolerance is in
+// coordinate units. Holes that collapse are removed, and nil is returned
+// when the exterior collapses. A nil opts uses the DefaultSimplifyOptions.
+func (poly *Poly) Simplify(tolerance float64, opts *SimplifyOptions) *Poly {
+	polys := SimplifyPolys([]*Poly{poly}, tolerance, opts)
+	if len(polys) == 0 {
+		return nil
+	}
+	return polys[0]
+}
+
+// SimplifyPolys returns simplified polygons, such as the polygons of a
+// MultiPolygon. When preserving topology, the rings of all of the polygons
+// are kept from crossing each other. Polygons that collapse are removed.
+// A nil opts uses the DefaultSimplifyOptions.
+func SimplifyPolys(polys []*Poly, tolerance float64, opts *SimplifyOptions,
+) []*Poly {
+	if opts == nil {
+		opts = DefaultSimplifyOptions
+	}
+	polys = nonEmptyPolys(polys)
+	if !opts.PreserveTopology {
+		var simplified []*Poly
+		for _, poly := range polys {
+			exterior := simplifyPoints(ringPoints(poly.Exterior), tolerance, opts)
+			if len(exterior) < 4 {
+				continue
+			}
+			var holes [][]Point
+			for _, hole := range poly.Holes {
+				hole := simplifyPoints(ringPoints(hole), tolerance, opts)
+				if len(hole) >= 4 {
+					holes = append(holes, hole)
+				}
+			}
+			simplified = append(simplified,
+				NewPoly(exterior, holes, opts.IndexOptions))
+		}
+		return simplified
+	}
+	// All rings start as the originals, and are replaced one at a time with
+	// their simplified versions, which must not cross the current rings.
+	var rings [][]Ring
+	for _, poly := range polys {
+		rings = append(rings, polyRings(poly))
+	}
+	for i, poly := range polys {
+		for j, ring := range polyRings(poly) {
+			points := ringPoints(ring)
+			if seriesSelfIntersects(ring, true) {
+				continue
+			}
+			// Only the rings whose rectangles intersect the ring's rectangle
+			// can cross a simplified version, or be inside of it, because the
+			// simplified rings are within the rectangles of the originals.
+			rect := ring.Rect()
+			var near [][2]int
+			for k, others := range rings {
+				for l, other := range others {
+					if (k != i || l != j) && rect.IntersectsRect(other.Rect()) {
+						near = append(near, [2]int{k, l})
+					}
+				}
+			}
+			simplified := simplifyValid(points, tolerance, opts,
+				func(simplified []Point) bool {
+					if len(simplified) < 4 {
+						return false
+					}
+					candidate := newRing(simplified, opts.IndexOptions)
+					if candidate.Clockwise() != ring.Clockwise() ||
+						seriesSelfIntersects(candidate, true) {
+						return false
+					}
+					for _, kl := range near {
+						other := rings[kl[0]][kl[1]]
+						if seriesIntersectsSeries(candidate, other) {
+							return false
+						}
+						// rings that were inside or outside of the ring
+						// must stay that way, which also keeps holes inside
+						// of their exteriors
+						first := other.PointAt(0)
+						if ringContainsPoint(candidate, first, false).hit !=
+							ringContainsPoint(ring, first, false).hit {
+							return false
+						}
+					}
+					return true
+				})
+			rings[i][j] = newRing(simplified, opts.IndexOptions)
+		}
+	}
+	simplified := make([]*Poly, len(rings))
+	for i, pr := range rings {
+		simplified[i] = &Poly{Exterior: pr[0], Holes: pr[1:]}
+	}
+	return simplified
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Joshua J Baker. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package geometry
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSimplifyLine(t *testing.T) {
+	line := L(P(0, 0), P(1, 0.1), P(2, -0.1), P(3, 5), P(4, 6), P(5, 7), P(6, 8.1),
+		P(7, 9), P(8, 9), P(9, 9))
+	dp := line.Simplify(1, nil)
+	expect(t, reflect.DeepEqual(seriesPoints(dp),
+		[]Point{{0, 0}, {2, -0.1}, {3, 5}, {7, 9}, {9, 9}}))
+	// only the collinear points are removed
+	expect(t, line.Simplify(0, nil).NumPoints() == line.NumPoints()-2)
+	expect(t, line.Simplify(100, nil).NumPoints() == 2)
+
+	vw := line.Simplify(1, &SimplifyOptions{Algorithm: VisvalingamWhyatt})
+	expect(t, vw.PointAt(0) == P(0, 0) && vw.PointAt(vw.NumPoints()-1) == P(9, 9))
+	expect(t, vw.NumPoints() < line.NumPoints() && vw.NumPoints() > 2)
+	expect(t, line.Simplify(100,
+		&SimplifyOptions{Algorithm: VisvalingamWhyatt}).NumPoints() == 3)
+	expect(t, L(P(0, 0), P(1, 1)).Simplify(10, nil).NumPoints() == 2)
+	expect(t, (*Line)(nil).Simplify(1, nil) == nil)
+
+	// a line that would cross itself
+	hook := L(P(0, 0), P(5, 0.8), P(10, 0), P(10, -3), P(5, -3), P(5, 0.4))
+	expect(t, !seriesSelfIntersects(hook, false))
+	expect(t, seriesSelfIntersects(hook.Simplify(1, nil), false))
+	simple := hook.Simplify(1, &SimplifyOptions{PreserveTopology: true})
+	expect(t, !seriesSelfIntersects(simple, false))
+	expect(t, simple.PointAt(1) == P(5, 0.8))
+}
+
+func TestSimplifyPoly(t *testing.T) {
+	texas := NewPoly(tx, nil, DefaultIndexOptions)
+	for _, alg := range []SimplifyAlgorithm{DouglasPeucker, VisvalingamWhyatt} {
+		for _, topo := range []bool{false, true} {
+			opts := &SimplifyOptions{Algorithm: alg, PreserveTopology: topo}
+			simple := texas.Simplify(0.05, opts)
+			expect(t, simple.Exterior.NumPoints() < texas.Exterior.NumPoints()/2)
+			expect(t, math.Abs(simple.Area()-texas.Area()) < texas.Area()/100)
+			if topo {
+				expect(t, !seriesSelfIntersects(simple.Exterior, true))
+			}
+		}
+	}
+	expect(t, VisvalingamWhyatt.String() == "VisvalingamWhyatt")
+
+	// collapsing
+	square := NewPoly(rectangle, nil, nil)
+	expect(t, square.Simplify(100, nil) == nil)
+	kept := square.Simplify(100, &SimplifyOptions{PreserveTopology: true})
+	expect(t, kept.Area() == 100)
+
+	// a hole near the edge of a bumpy exterior
+	bumpy := []Point{{0, 0}, {5, -1}, {10, 0}, {10, 10}, {5, 7}, {0, 10}, {0, 0}}
+	hole := []Point{{3, 3}, {7, 3}, {7, 7.5}, {5, 6.5}, {3, 7.5}, {3, 3}}
+	poly := NewPoly(bumpy, [][]Point{hole}, nil)
+	loose := poly.Simplify(2, nil)
+	expect(t, loose.Exterior.NumPoints() == 6 && loose.Holes[0].NumPoints() == 5)
+	expect(t, seriesIntersectsSeries(loose.Exterior, loose.Holes[0]))
+	strict := poly.Simplify(2, &SimplifyOptions{PreserveTopology: true})
+	expect(t, len(strict.Holes) == 1)
+	expect(t, strict.Exterior.NumPoints() == 6)
+	expect(t, !seriesIntersectsSeries(strict.Exterior, strict.Holes[0]))
+	expect(t, strict.ContainsPoint(P(5, 6.8)))
+
+	// holes that collapse are removed
+	tiny := []Point{{4, 4}, {4.1, 4}, {4.1, 4.1}, {4, 4}}
+	expect(t, len(NewPoly(rectangle, [][]Point{tiny}, nil).
+		Simplify(1, nil).Holes) == 0)
+
+	// polygons are kept apart
+	a := NewPoly([]Point{{0, 0}, {10, 0}, {10, 4}, {5, 6}, {0, 4}, {0, 0}}, nil, nil)
+	b := NewPoly([]Point{{0, 5}, {5, 7}, {10, 5}, {10, 10}, {0, 10}, {0, 5}}, nil, nil)
+	polys := SimplifyPolys([]*Poly{a, b}, 3,
+		&SimplifyOptions{PreserveTopology: true})
+	expect(t, len(polys) == 2)
+	expect(t, !seriesIntersectsSeries(polys[0].Exterior, polys[1].Exterior))
+
+	// many polygons that are apart are the same as each one on its own
+	opts := &SimplifyOptions{PreserveTopology: true}
+	var many []*Poly
+	for i := 0; i < 2000; i++ {
+		many = append(many, poly.Move(float64(i%50)*20, float64(i/50)*20))
+	}
+	polys = SimplifyPolys(many, 2, opts)
+	expect(t, len(polys) == len(many))
+	for i, simple := range polys {
+		alone := many[i].Simplify(2, opts)
+		expect(t, simple.Exterior.NumPoints() == alone.Exterior.NumPoints())
+		expect(t, len(simple.Holes) == len(alone.Holes))
+	}
+}
@@ -18,11 +18,12 @@ func NewGeometryCollection(geometries []Object) *GeometryCollection {
 // AppendJSON appends the GeoJSON reprensentation to dst
 func (g *GeometryCollection) AppendJSON(dst []byte) []byte {
 	dst = append(dst, `{"type":"GeometryCollection","geometries":[`...)
-	for i := 0; i < len(g.children); i++ {
+	children := g.Children()
+	for i := 0; i < len(children); i++ {
 		if i > 0 {
 			dst = append(dst, ',')
 		}
-		dst = g.children[i].AppendJSON(dst)
+		dst = children[i].AppendJSON(dst)
 	}
 	dst = append(dst, ']')
 	if g.extra != nil {
@@ -45,6 +46,46 @@ func (g *GeometryCollection) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *GeometryCollection) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *GeometryCollection) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binGeometryCollection)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*GeometryCollection)
+	return nil
+}
+
+// Append adds geometries to the end of the collection, and to its index. The
+// methods that change a collection, which are Append, Remove, Replace, and
+// Filter, are safe for a single writer with concurrent readers. A slice that
+// was returned by Children doesn't change.
+func (g *GeometryCollection) Append(geometries ...Object) {
+	g.append(geometries)
+}
+
+// Remove removes the geometry at an index and returns it.
+func (g *GeometryCollection) Remove(index int) Object {
+	return g.remove(index)
+}
+
+// Replace replaces the geometry at an index and returns the old one.
+func (g *GeometryCollection) Replace(index int, geometry Object) Object {
+	return g.replace(index, geometry)
+}
+
+// Filter keeps the geometries that keep returns true for, and removes the
+// rest. It returns the number that were removed. The keep function must not
+// use the collection.
+func (g *GeometryCollection) Filter(keep func(geometry Object) bool) int {
+	return g.filter(keep)
+}
+
 func parseJSONGeometryCollection(
 	keys *parseKeys, opts *ParseOptions,
 ) (Object, error) {
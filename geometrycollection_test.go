@@ -22,3 +22,18 @@ func TestGeometryCollectionValid(t *testing.T) {
 	expectJSON(t, json, nil)
 	expectJSONOpts(t, json, errCoordinatesInvalid, &ParseOptions{RequireValid: true})
 }
+
+func TestGeometryCollectionChange(t *testing.T) {
+	gc := NewGeometryCollection([]Object{PO(1, 1), PO(2, 2)})
+	gc.Append(PO(3, 3), PO(4, 4))
+	expect(t, gc.Rect() == R(1, 1, 4, 4))
+	gc.Replace(0, PO(0, 0))
+	gc.Remove(3)
+	expect(t, gc.Rect() == R(0, 0, 3, 3))
+	expect(t, gc.Filter(func(geometry Object) bool {
+		return geometry.Center() != P(2, 2)
+	}) == 1)
+	expectJSON(t, gc.JSON(), `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]},{"type":"Point","coordinates":[3,3]}]}`)
+	expect(t, gc.Intersects(PO(3, 3)))
+	expect(t, !gc.Intersects(PO(2, 2)))
+}
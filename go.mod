@@ -3,6 +3,7 @@ module github.com/tidwall/geojson
 go 1.15
 
 require (
+	github.com/tidwall/geoindex v1.4.4
 	github.com/tidwall/gjson v1.12.1
 	github.com/tidwall/lotsa v1.0.2
 	github.com/tidwall/pretty v1.2.0
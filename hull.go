@@ -0,0 +1,55 @@
+package geojson
+
+import "github.com/tidwall/geojson/geometry"
+
+// hullPoints returns the points of an object. Only the exteriors of polygons
+// are used, because their holes are inside of them.
+func hullPoints(parts []objectPart) []geometry.Point {
+	var points []geometry.Point
+	for _, part := range parts {
+		var series geometry.Series
+		switch {
+		case part.poly != nil:
+			series = part.poly.Exterior
+		case part.line != nil:
+			series = part.line
+		default:
+			points = append(points, part.point)
+			continue
+		}
+		for i := 0; i < series.NumPoints(); i++ {
+			points = append(points, series.PointAt(i))
+		}
+	}
+	return points
+}
+
+// hullObject returns a hull as a Polygon. A hull of points that are all on a
+// line is a LineString, and a hull of points that are all the same is a
+// Point. The hull of an empty object is an empty MultiPolygon.
+func hullObject(hull []geometry.Point) Object {
+	switch len(hull) {
+	case 0:
+		return NewMultiPolygon(nil)
+	case 1:
+		return NewPoint(hull[0])
+	case 2:
+		return NewLineString(geometry.NewLine(hull, geometry.DefaultIndexOptions))
+	}
+	return NewPolygon(geometry.NewPoly(hull, nil, geometry.DefaultIndexOptions))
+}
+
+func convexHull(obj Object) Object {
+	parts := appendObjectParts(nil, obj, 0, true)
+	if len(parts) == 1 && parts[0].poly != nil &&
+		parts[0].poly.Exterior.Convex() {
+		// the exterior of a convex polygon is already its hull
+		return NewPolygon(&geometry.Poly{Exterior: parts[0].poly.Exterior})
+	}
+	return hullObject(geometry.ConvexHull(hullPoints(parts)))
+}
+
+func concaveHull(obj Object, ratio float64) Object {
+	parts := appendObjectParts(nil, obj, 0, true)
+	return hullObject(geometry.ConcaveHull(hullPoints(parts), ratio))
+}
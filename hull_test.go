@@ -0,0 +1,59 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestHull(t *testing.T) {
+	mp := MPO([]geometry.Point{P(0, 0), P(10, 0), P(5, 5), P(10, 10), P(0, 10)})
+	expect(t, mp.ConvexHull().JSON() ==
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`)
+
+	// device positions in a feature collection
+	fc := expectJSON(t, `{"type":"FeatureCollection","features":[`+
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":{}},`+
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[4,1]},"properties":{}},`+
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[2,3]},"properties":{}},`+
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[2,1]},"properties":{}}`+
+		`]}`, nil)
+	expect(t, fc.ConvexHull().JSON() ==
+		`{"type":"Polygon","coordinates":[[[0,0],[4,1],[2,3],[0,0]]]}`)
+
+	// a convex polygon is its own hull, without the holes
+	holed := expectJSON(t, `{"type":"Polygon","coordinates":[`+
+		`[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`,
+		nil)
+	expect(t, holed.ConvexHull().JSON() ==
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`)
+	expect(t, RO(0, 0, 10, 10).ConvexHull().(*Polygon).Area() == 100)
+	circle := NewCircle(P(0, 0), 1000, 16)
+	expect(t, circle.ConvexHull().(*Polygon).Rect() == circle.Rect())
+
+	// degenerate hulls
+	expect(t, PO(1, 2).ConvexHull().JSON() ==
+		`{"type":"Point","coordinates":[1,2]}`)
+	line := LO([]geometry.Point{P(0, 0), P(1, 1), P(2, 2)})
+	expect(t, line.ConvexHull().JSON() ==
+		`{"type":"LineString","coordinates":[[0,0],[2,2]]}`)
+	expect(t, line.ConcaveHull(0).JSON() ==
+		`{"type":"LineString","coordinates":[[0,0],[2,2]]}`)
+	expect(t, MPO(nil).ConvexHull().JSON() ==
+		`{"type":"MultiPolygon","coordinates":[]}`)
+
+	// a cluster in the shape of the letter C
+	var points []geometry.Point
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			if x < 3 || y < 3 || y > 7 {
+				points = append(points, P(float64(x), float64(y)))
+			}
+		}
+	}
+	cluster := MPO(points)
+	concave := cluster.ConcaveHull(0).(*Polygon)
+	expect(t, concave.Area() == 52)
+	expect(t, concave.Contains(cluster) && !concave.Contains(PO(6, 5)))
+	expect(t, cluster.ConcaveHull(1).(*Polygon).Area() == 100)
+}
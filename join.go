@@ -0,0 +1,179 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geoindex/child"
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/rtree"
+)
+
+// JoinPredicate is the test that a pair of children must pass to be joined.
+type JoinPredicate int
+
+const (
+	// JoinIntersects joins the children that intersect.
+	JoinIntersects JoinPredicate = iota
+	// JoinContains joins the children of the first collection with the
+	// children of the second collection that they contain.
+	JoinContains
+	// JoinWithin joins the children of the first collection with the
+	// children of the second collection that they are within.
+	JoinWithin
+	// JoinDistance joins the children that are within a distance in meters
+	// of each other.
+	JoinDistance
+)
+
+// JoinOptions are the options for Join.
+type JoinOptions struct {
+	// Predicate is the test that a pair of children must pass.
+	// The default is JoinIntersects.
+	Predicate JoinPredicate
+	// Meters is the distance for the JoinDistance predicate.
+	Meters float64
+}
+
+// DefaultJoinOptions are the JoinOptions that are used when none are given.
+var DefaultJoinOptions = &JoinOptions{
+	Predicate: JoinIntersects,
+	Meters:    0,
+}
+
+// joinCollection is a collection with an index that can be walked.
+type joinCollection interface {
+	joinTree() *rtree.RTree
+}
+
+// joinTree returns a snapshot of the collection's index. A collection that
+// isn't indexed has a temporary index.
+func (g *collection) joinTree() *rtree.RTree {
+	tree, children := g.snapshot()
+	if tree != nil {
+		return tree
+	}
+	temp := &collection{children: children}
+	temp.buildIndex()
+	return temp.tree
+}
+
+// collectionTree returns an index of a collection.
+func collectionTree(c Collection) *rtree.RTree {
+	if c, ok := c.(joinCollection); ok {
+		return c.joinTree()
+	}
+	temp := &collection{children: c.Children()}
+	temp.buildIndex()
+	return temp.tree
+}
+
+// joinPad returns a rectangle that is larger by a distance in meters on
+// every side.
+func joinPad(min, max [2]float64, meters float64) (pmin, pmax [2]float64) {
+	if meters <= 0 {
+		return min, max
+	}
+	lat := meters / geo.DistanceTo(0, 0, 1, 0)
+	pmin[1], pmax[1] = min[1]-lat, max[1]+lat
+	// a degree of longitude is shortest at the latitude nearest to a pole
+	cos := math.Cos(math.Max(math.Abs(pmin[1]), math.Abs(pmax[1])) *
+		math.Pi / 180)
+	lon := lat / cos
+	if pmin[1] <= -90 || pmax[1] >= 90 || lon >= 180 {
+		// near a pole, so every longitude may be within the distance
+		return [2]float64{-180, pmin[1]}, [2]float64{180, pmax[1]}
+	}
+	pmin[0], pmax[0] = min[0]-lon, max[0]+lon
+	return pmin, pmax
+}
+
+func joinIntersects(amin, amax, bmin, bmax [2]float64) bool {
+	return !(bmin[0] > amax[0] || bmax[0] < amin[0] ||
+		bmin[1] > amax[1] || bmax[1] < amin[1])
+}
+
+// joinNear returns true if two rectangles are within a distance in meters of
+// each other. A padded rectangle that goes past the antimeridian is also
+// tested on the other side of it.
+func joinNear(amin, amax, bmin, bmax [2]float64, meters float64) bool {
+	pmin, pmax := joinPad(amin, amax, meters)
+	if joinIntersects(pmin, pmax, bmin, bmax) {
+		return true
+	}
+	if pmin[0] < -180 && joinIntersects([2]float64{pmin[0] + 360, pmin[1]},
+		[2]float64{180, pmax[1]}, bmin, bmax) {
+		return true
+	}
+	return pmax[0] > 180 && joinIntersects([2]float64{-180, pmin[1]},
+		[2]float64{pmax[0] - 360, pmax[1]}, bmin, bmax)
+}
+
+func joinArea(c child.Child) float64 {
+	return (c.Max[0] - c.Min[0]) * (c.Max[1] - c.Min[1])
+}
+
+// Join calls iter for each pair of children from two collections that pass
+// a predicate, with the child of the first collection as a. The indexes of
+// both collections are walked together, so only the children that are near
+// each other are tested. A collection that isn't indexed is indexed for the
+// join. The join sees the collections as they were when it started, so iter
+// may change them. Joining a collection with itself also tests each child
+// with itself, and yields both (x, y) and (y, x) for a pair of children. A
+// nil opts uses the DefaultJoinOptions.
+func Join(a, b Collection, opts *JoinOptions, iter func(a, b Object) bool) {
+	if opts == nil {
+		opts = DefaultJoinOptions
+	}
+	treeA := collectionTree(a)
+	treeB := treeA
+	if b != a {
+		treeB = collectionTree(b)
+	}
+	var meters float64
+	match := func(a, b Object) bool { return a.Intersects(b) }
+	switch opts.Predicate {
+	case JoinContains:
+		match = func(a, b Object) bool { return a.Contains(b) }
+	case JoinWithin:
+		match = func(a, b Object) bool { return a.Within(b) }
+	case JoinDistance:
+		meters = opts.Meters
+		match = func(a, b Object) bool {
+			_, near := a.ClosestPair(b)
+			return near.Meters <= meters
+		}
+	}
+
+	var walk func(ca, cb child.Child) bool
+	walk = func(ca, cb child.Child) bool {
+		if !joinNear(ca.Min, ca.Max, cb.Min, cb.Max, meters) {
+			return true
+		}
+		switch {
+		case ca.Item && cb.Item:
+			a, b := ca.Data.(Object), cb.Data.(Object)
+			if match(a, b) && !iter(a, b) {
+				return false
+			}
+		case cb.Item || (!ca.Item && joinArea(ca) >= joinArea(cb)):
+			// walk into the larger node
+			for _, ca := range treeA.Children(ca.Data, nil) {
+				if !walk(ca, cb) {
+					return false
+				}
+			}
+		default:
+			for _, cb := range treeB.Children(cb.Data, nil) {
+				if !walk(ca, cb) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	rootsA := treeA.Children(nil, nil)
+	rootsB := treeB.Children(nil, nil)
+	if len(rootsA) > 0 && len(rootsB) > 0 {
+		walk(rootsA[0], rootsB[0])
+	}
+}
@@ -0,0 +1,121 @@
+package geojson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	var points, rects []Object
+	for i := 0; i < 200; i++ {
+		x, y := r.Float64()*40-20, r.Float64()*40-20
+		points = append(points, PO(x, y))
+	}
+	for i := 0; i < 100; i++ {
+		x, y := r.Float64()*40-20, r.Float64()*40-20
+		w, h := r.Float64()*3, r.Float64()*3
+		rects = append(rects, RO(x, y, x+w, y+h))
+	}
+	type pair struct{ a, b Object }
+	brute := func(as, bs []Object, opts *JoinOptions) map[pair]bool {
+		pairs := make(map[pair]bool)
+		for _, a := range as {
+			for _, b := range bs {
+				var ok bool
+				switch opts.Predicate {
+				case JoinIntersects:
+					ok = a.Intersects(b)
+				case JoinContains:
+					ok = a.Contains(b)
+				case JoinWithin:
+					ok = a.Within(b)
+				case JoinDistance:
+					_, near := a.ClosestPair(b)
+					ok = near.Meters <= opts.Meters
+				}
+				if ok {
+					pairs[pair{a, b}] = true
+				}
+			}
+		}
+		return pairs
+	}
+	join := func(a, b Collection, opts *JoinOptions) map[pair]bool {
+		pairs := make(map[pair]bool)
+		Join(a, b, opts, func(a, b Object) bool {
+			expect(t, !pairs[pair{a, b}])
+			pairs[pair{a, b}] = true
+			return true
+		})
+		return pairs
+	}
+	flatPoints := NewFeatureCollection(nil)
+	flatPoints.children = points
+	flatPoints.parseInitRectIndex(&ParseOptions{IndexChildren: 0})
+	indexedPoints := NewFeatureCollection(points)
+	indexedRects := NewGeometryCollection(rects)
+	expect(t, indexedPoints.Indexed() && indexedRects.Indexed())
+	expect(t, !flatPoints.Indexed())
+
+	var total int
+	for _, opts := range []*JoinOptions{
+		{Predicate: JoinIntersects},
+		{Predicate: JoinContains},
+		{Predicate: JoinWithin},
+		{Predicate: JoinDistance, Meters: 50000},
+	} {
+		for _, pts := range []Collection{indexedPoints, flatPoints} {
+			for _, order := range [][2]Collection{
+				{indexedRects, pts}, {pts, indexedRects},
+			} {
+				expected := brute(order[0].Children(), order[1].Children(), opts)
+				pairs := join(order[0], order[1], opts)
+				total += len(expected)
+				expect(t, len(pairs) == len(expected))
+				for p := range expected {
+					expect(t, pairs[p])
+				}
+			}
+		}
+	}
+
+	expect(t, total > 0)
+
+	// join with itself, which has each child with itself, and both orders
+	pairs := join(indexedRects, indexedRects, nil)
+	expect(t, len(pairs) == len(brute(rects, rects, DefaultJoinOptions)))
+	for _, rect := range rects {
+		expect(t, pairs[pair{rect, rect}])
+	}
+	for p := range pairs {
+		expect(t, pairs[pair{p.b, p.a}])
+	}
+
+	// across the antimeridian
+	east := NewFeatureCollection([]Object{PO(179.9999, 0), PO(170, 0)})
+	west := NewFeatureCollection([]Object{PO(-179.9999, 0), PO(-170, 0)})
+	opts := &JoinOptions{Predicate: JoinDistance, Meters: 1000}
+	for _, order := range [][2]Collection{{east, west}, {west, east}} {
+		pairs = join(order[0], order[1], opts)
+		expect(t, len(pairs) == 1)
+		expect(t, len(pairs) == len(brute(order[0].Children(),
+			order[1].Children(), opts)))
+	}
+
+	// stop early
+	var count int
+	Join(indexedRects, indexedPoints, nil, func(a, b Object) bool {
+		count++
+		return count < 3
+	})
+	expect(t, count == 3)
+
+	// empty
+	count = 0
+	Join(NewFeatureCollection(nil), indexedPoints, nil, func(a, b Object) bool {
+		count++
+		return true
+	})
+	expect(t, count == 0)
+}
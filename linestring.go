@@ -44,6 +44,36 @@ func (g *LineString) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *LineString) AppendWKT(dst []byte) []byte {
+	return appendWKTTagged(dst, "LINESTRING", g)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *LineString) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *LineString) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *LineString) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
+// Length returns the planar length in coordinate units.
+func (g *LineString) Length() float64 {
+	return g.base.Length()
+}
+
+// GeodesicLength returns the length in meters on the WGS84 ellipsoid.
+func (g *LineString) GeodesicLength() float64 {
+	return geodesicSeriesLength(&g.base)
+}
+
 func (g *LineString) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -56,6 +86,21 @@ func (g *LineString) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *LineString) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *LineString) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binLineString)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*LineString)
+	return nil
+}
+
 func (g *LineString) Spatial() Spatial {
 	return g
 }
@@ -73,6 +118,9 @@ func (g *LineString) Contains(obj Object) bool {
 }
 
 func (g *LineString) Intersects(obj Object) bool {
+	if obj, ok := obj.(*Circle); ok {
+		return obj.Intersects(g)
+	}
 	return obj.Spatial().IntersectsLine(&g.base)
 }
 
@@ -138,6 +186,14 @@ func parseJSONLineString(keys *parseKeys, opts *ParseOptions) (Object, error) {
 	return &g, nil
 }
 
+// makeLineString returns a LineString from already parsed points.
+func makeLineString(
+	points []geometry.Point, ex *extra, opts *ParseOptions,
+) *LineString {
+	gopts := toGeometryOpts(opts)
+	return &LineString{base: *geometry.NewLine(points, &gopts), extra: ex}
+}
+
 func parseJSONLineStringCoords(
 	keys *parseKeys, rcoords gjson.Result, opts *ParseOptions,
 ) ([]geometry.Point, *extra, error) {
@@ -209,25 +265,106 @@ func parseJSONLineStringCoords(
 	return coords, ex, err
 }
 
+// Centroid returns the centroid of the line, weighted by length.
+func (g *LineString) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns a vertex of the line.
+func (g *LineString) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns a vertex of the line.
+func (g *LineString) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *LineString) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *LineString) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *LineString) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *LineString) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *LineString) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *LineString) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *LineString) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *LineString) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the line.
+func (g *LineString) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the line.
+func (g *LineString) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the line.
+func (g *LineString) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the line to a point.
+func (g *LineString) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the line and obj.
+func (g *LineString) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *LineString) Distance(obj Object) float64 {
 	return obj.Spatial().DistanceLine(&g.base)
 }
 
 func (g *LineString) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return distPointLine(point, &g.base)
 }
 
 // DistanceRect ..
 func (g *LineString) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return distLinePoly(&g.base, rectPoly(rect))
 }
 
 func (g *LineString) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return distLineLine(&g.base, line)
 }
 
 func (g *LineString) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return distLinePoly(&g.base, poly)
 }
 
 func (g *LineString) Members() string {
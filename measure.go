@@ -0,0 +1,72 @@
+package geojson
+
+import (
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+)
+
+// areaObject is an object that has an area, such as a Polygon.
+type areaObject interface {
+	Area() float64
+	GeodesicArea() float64
+}
+
+// lengthObject is an object that has a length, such as a LineString, or a
+// perimeter, such as a Polygon.
+type lengthObject interface {
+	Length() float64
+	GeodesicLength() float64
+}
+
+// geodesicSeriesLength returns the length in meters of all segments in a
+// series on the WGS84 ellipsoid.
+func geodesicSeriesLength(series geometry.Series) float64 {
+	var meters float64
+	n := series.NumSegments()
+	for i := 0; i < n; i++ {
+		seg := series.SegmentAt(i)
+		meters += geo.EllipsoidDistance(seg.A.Y, seg.A.X, seg.B.Y, seg.B.X)
+	}
+	return meters
+}
+
+// geodesicSeriesArea returns the area in square meters of the shape formed
+// by a closed series on the WGS84 ellipsoid.
+func geodesicSeriesArea(series geometry.Series) float64 {
+	return geo.EllipsoidArea(series.NumPoints(), func(i int) (lat, lon float64) {
+		point := series.PointAt(i)
+		return point.Y, point.X
+	})
+}
+
+func geodesicPolyArea(poly *geometry.Poly) float64 {
+	if poly.Empty() {
+		return 0
+	}
+	area := geodesicSeriesArea(poly.Exterior)
+	for _, hole := range poly.Holes {
+		area -= geodesicSeriesArea(hole)
+	}
+	return area
+}
+
+func geodesicPolyLength(poly *geometry.Poly) float64 {
+	if poly.Empty() {
+		return 0
+	}
+	meters := geodesicSeriesLength(poly.Exterior)
+	for _, hole := range poly.Holes {
+		meters += geodesicSeriesLength(hole)
+	}
+	return meters
+}
+
+// sumChildren returns the sum of a measurement of the children that have it.
+func sumChildren(children []Object, measure func(child Object) float64,
+) float64 {
+	var sum float64
+	for _, child := range children {
+		sum += measure(child)
+	}
+	return sum
+}
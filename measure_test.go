@@ -0,0 +1,60 @@
+package geojson
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestArea(t *testing.T) {
+	poly := expectJSON(t,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]],[[0.25,0.25],[0.75,0.25],[0.75,0.75],[0.25,0.75],[0.25,0.25]]]}`,
+		nil).(*Polygon)
+	expect(t, poly.Area() == 0.75)
+	// a one degree cell on the equator is about 12308.8 square km
+	expect(t, math.Abs(poly.GeodesicArea()-12308.8e6*0.75) < 1e6)
+	expect(t, poly.Length() == 6)
+	expect(t, math.Abs(poly.GeodesicLength()-
+		1.5*(2*111319.491+2*110574.389)) < 200)
+
+	rect := RO(0, 0, 1, 1)
+	expect(t, rect.Area() == 1 && rect.Length() == 4)
+	expect(t, math.Abs(rect.GeodesicArea()-12308.8e6) < 1e6)
+	expect(t, math.Abs(rect.GeodesicLength()-
+		(2*111319.491+2*110574.389)) < 50)
+
+	mp := expectJSON(t,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1],[0,0]]],[[[2,0],[4,0],[4,1],[2,1],[2,0]]]]}`,
+		nil).(*MultiPolygon)
+	expect(t, mp.Area() == 3 && mp.Length() == 10)
+	expect(t, math.Abs(mp.GeodesicArea()-3*12308.8e6) < 3e6)
+	expect(t, mp.GeodesicLength() > 0)
+
+	c := NewCircle(P(-112, 33), 1000, 64)
+	expect(t, math.Abs(c.GeodesicArea()-math.Pi*1e6)/(math.Pi*1e6) < 0.005)
+	// the 64 step polygon is short by the area between its sides and the arcs
+	step := 2 * math.Pi / 64
+	poly64 := c.getObject().(*Polygon).GeodesicArea()
+	expect(t, math.Abs(c.GeodesicArea()*math.Sin(step)/step-poly64)/
+		poly64 < 1e-5)
+	expect(t, c.Area() > 0)
+	// the parts on each side of the antimeridian
+	split := NewCircle(P(180, 0), 1000, 64)
+	expect(t, math.Abs(split.GeodesicArea()-c.GeodesicArea())/
+		c.GeodesicArea() < 0.005)
+}
+
+func TestLength(t *testing.T) {
+	line := LO([]geometry.Point{P(0, 0), P(3, 0), P(3, 4)})
+	expect(t, line.Length() == 7)
+	expect(t, math.Abs(line.GeodesicLength()-
+		(geo.EllipsoidDistance(0, 0, 0, 3)+geo.EllipsoidDistance(0, 3, 4, 3))) < 1e-6)
+	ml := expectJSON(t,
+		`{"type":"MultiLineString","coordinates":[[[0,0],[3,0]],[[0,0],[0,4]]]}`,
+		nil).(*MultiLineString)
+	expect(t, ml.Length() == 7)
+	expect(t, math.Abs(ml.GeodesicLength()-line.GeodesicLength()) < 1e-6)
+	expect(t, LO(nil).Length() == 0 && LO(nil).GeodesicLength() == 0)
+}
@@ -34,6 +34,47 @@ func (g *MultiLineString) AppendJSON(dst []byte) []byte {
 
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *MultiLineString) AppendWKT(dst []byte) []byte {
+	return appendWKTMulti(dst, "MULTILINESTRING", g.children)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *MultiLineString) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *MultiLineString) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *MultiLineString) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
+// Length returns the sum of the planar line lengths in coordinate units.
+func (g *MultiLineString) Length() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(lengthObject); ok {
+			return child.Length()
+		}
+		return 0
+	})
+}
+
+// GeodesicLength returns the sum of the line lengths in meters on the WGS84
+// ellipsoid.
+func (g *MultiLineString) GeodesicLength() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(lengthObject); ok {
+			return child.GeodesicLength()
+		}
+		return 0
+	})
+}
+
 func (g *MultiLineString) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -56,6 +97,21 @@ func (g *MultiLineString) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *MultiLineString) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *MultiLineString) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binMultiLineString)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*MultiLineString)
+	return nil
+}
+
 func parseJSONMultiLineString(
 	keys *parseKeys, opts *ParseOptions,
 ) (Object, error) {
@@ -33,6 +33,26 @@ func (g *MultiPoint) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *MultiPoint) AppendWKT(dst []byte) []byte {
+	return appendWKTMulti(dst, "MULTIPOINT", g.children)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *MultiPoint) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *MultiPoint) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *MultiPoint) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *MultiPoint) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -45,6 +65,21 @@ func (g *MultiPoint) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *MultiPoint) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *MultiPoint) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binMultiPoint)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*MultiPoint)
+	return nil
+}
+
 func parseJSONMultiPoint(keys *parseKeys, opts *ParseOptions) (Object, error) {
 	var g MultiPoint
 	var err error
@@ -33,6 +33,91 @@ func (g *MultiPolygon) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *MultiPolygon) AppendWKT(dst []byte) []byte {
+	return appendWKTMulti(dst, "MULTIPOLYGON", g.children)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *MultiPolygon) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *MultiPolygon) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *MultiPolygon) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
+// Area returns the sum of the planar areas of the polygons in square
+// coordinate units.
+func (g *MultiPolygon) Area() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(areaObject); ok {
+			return child.Area()
+		}
+		return 0
+	})
+}
+
+// GeodesicArea returns the sum of the areas of the polygons in square meters
+// on the WGS84 ellipsoid.
+func (g *MultiPolygon) GeodesicArea() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(areaObject); ok {
+			return child.GeodesicArea()
+		}
+		return 0
+	})
+}
+
+// Union returns the area that is in either the object or another object.
+func (g *MultiPolygon) Union(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayUnion)
+}
+
+// Intersection returns the area that is in both the object and another
+// object.
+func (g *MultiPolygon) Intersection(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayIntersection)
+}
+
+// Difference returns the area of the object that is not in another object.
+func (g *MultiPolygon) Difference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayDifference)
+}
+
+// SymmetricDifference returns the area that is in only one of the object and
+// another object.
+func (g *MultiPolygon) SymmetricDifference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlaySymmetricDifference)
+}
+
+// Length returns the sum of the planar polygon perimeters in coordinate units.
+func (g *MultiPolygon) Length() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(lengthObject); ok {
+			return child.Length()
+		}
+		return 0
+	})
+}
+
+// GeodesicLength returns the sum of the polygon perimeters in meters on the WGS84
+// ellipsoid.
+func (g *MultiPolygon) GeodesicLength() float64 {
+	return sumChildren(g.children, func(child Object) float64 {
+		if child, ok := child.(lengthObject); ok {
+			return child.GeodesicLength()
+		}
+		return 0
+	})
+}
+
 func (g *MultiPolygon) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -55,6 +140,21 @@ func (g *MultiPolygon) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *MultiPolygon) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *MultiPolygon) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binMultiPolygon)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*MultiPolygon)
+	return nil
+}
+
 func parseJSONMultiPolygon(
 	keys *parseKeys, opts *ParseOptions,
 ) (Object, error) {
@@ -0,0 +1,97 @@
+package geojson
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tidwall/geoindex"
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+)
+
+// geoDistanceRect returns the distance in meters from a point to the nearest
+// location in a rectangle of longitudes and latitudes.
+func geoDistanceRect(point geometry.Point, rect geometry.Rect) float64 {
+	if point.X >= rect.Min.X && point.X <= rect.Max.X {
+		// the nearest location is due north or south
+		switch {
+		case point.Y < rect.Min.Y:
+			return geo.DistanceTo(point.Y, point.X, rect.Min.Y, point.X)
+		case point.Y > rect.Max.Y:
+			return geo.DistanceTo(point.Y, point.X, rect.Max.Y, point.X)
+		}
+		return 0
+	}
+	// The nearest location is on the west or east side, because the
+	// distance to the top and bottom grows with the difference in longitude.
+	west := geo.DistanceToSegment(point.Y, point.X,
+		rect.Min.Y, rect.Min.X, rect.Max.Y, rect.Min.X)
+	east := geo.DistanceToSegment(point.Y, point.X,
+		rect.Min.Y, rect.Max.X, rect.Max.Y, rect.Max.X)
+	return math.Min(west, east)
+}
+
+// nearbyDistance returns the distance in meters from a point to the nearest
+// location on an object.
+func nearbyDistance(obj Object, point geometry.Point) float64 {
+	if circle, ok := obj.(*Circle); ok {
+		meters := geoDistancePoints(circle.center, point) - circle.meters
+		return math.Max(meters, 0)
+	}
+	return obj.NearestPoint(point).Meters
+}
+
+// Nearby iterates over the children of the collection by their distance in
+// meters from a point, nearest first. No more than k children, and none that
+// are farther than maxMeters, are returned. A k or maxMeters of zero or less
+// has no limit. Indexed collections are searched best-first, so only the
+// children that are near the point have their distance measured.
+func (g *collection) Nearby(point geometry.Point, k int, maxMeters float64,
+	iter func(child Object, meters float64) bool,
+) {
+	var count int
+	add := func(child Object, meters float64) bool {
+		if maxMeters > 0 && meters > maxMeters {
+			return false
+		}
+		count++
+		return iter(child, meters) && (k <= 0 || count < k)
+	}
+	tree, children := g.snapshot()
+	if tree != nil {
+		geoindex.Wrap(tree).Nearby(
+			func(min, max [2]float64, data interface{}, item bool) float64 {
+				if item {
+					return nearbyDistance(data.(Object), point)
+				}
+				return geoDistanceRect(point, geometry.Rect{
+					Min: geometry.Point{X: min[0], Y: min[1]},
+					Max: geometry.Point{X: max[0], Y: max[1]},
+				})
+			},
+			func(_, _ [2]float64, data interface{}, meters float64) bool {
+				return add(data.(Object), meters)
+			},
+		)
+		return
+	}
+	type nearbyChild struct {
+		child  Object
+		meters float64
+	}
+	var all []nearbyChild
+	for _, child := range children {
+		if child.Empty() {
+			continue
+		}
+		all = append(all, nearbyChild{child, nearbyDistance(child, point)})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].meters < all[j].meters
+	})
+	for _, c := range all {
+		if !add(c.child, c.meters) {
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package geojson
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestNearby(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	var children []Object
+	for i := 0; i < 1000; i++ {
+		x, y := r.Float64()*360-180, r.Float64()*170-85
+		switch i % 4 {
+		case 0:
+			children = append(children, PO(x, y))
+		case 1:
+			children = append(children, RO(x, y, x+0.5, y+0.5))
+		case 2:
+			children = append(children, LO([]geometry.Point{
+				P(x, y), P(x+1, y+0.5), P(x+1.5, y+2)}))
+		case 3:
+			children = append(children, NewCircle(P(x, y), 20000, 16))
+		}
+	}
+	indexed := NewGeometryCollection(children)
+	flat := NewGeometryCollection(nil)
+	flat.children = children
+	flat.parseInitRectIndex(&ParseOptions{IndexChildren: 0})
+	expect(t, indexed.Indexed() && !flat.Indexed())
+
+	for i := 0; i < 20; i++ {
+		point := P(r.Float64()*360-180, r.Float64()*170-85)
+		var all []float64
+		for _, child := range children {
+			all = append(all, nearbyDistance(child, point))
+		}
+		sort.Float64s(all)
+		for _, g := range []*GeometryCollection{indexed, flat} {
+			var found []float64
+			g.Nearby(point, 10, 0, func(child Object, meters float64) bool {
+				expect(t, meters == nearbyDistance(child, point))
+				found = append(found, meters)
+				return true
+			})
+			expect(t, len(found) == 10)
+			for j := range found {
+				expect(t, math.Abs(found[j]-all[j]) < 1e-6)
+			}
+			found = nil
+			g.Nearby(point, 0, all[5], func(child Object, meters float64) bool {
+				found = append(found, meters)
+				return true
+			})
+			expect(t, len(found) == 6)
+		}
+	}
+
+	// stop early
+	var count int
+	indexed.Nearby(P(0, 0), 0, 0, func(child Object, meters float64) bool {
+		count++
+		return count < 3
+	})
+	expect(t, count == 3)
+
+	count = 0
+	NewMultiPoint(nil).Nearby(P(0, 0), 0, 0,
+		func(child Object, meters float64) bool {
+			count++
+			return true
+		})
+	expect(t, count == 0)
+}
+
+func TestGeoDistanceRect(t *testing.T) {
+	rect := R(10, 10, 20, 20)
+	expect(t, geoDistanceRect(P(15, 15), rect) == 0)
+	expect(t, geoDistanceRect(P(15, 5), rect) == geoDistancePoints(P(15, 5),
+		P(15, 10)))
+	expect(t, geoDistanceRect(P(15, 25), rect) == geoDistancePoints(P(15, 25),
+		P(15, 20)))
+	// the nearest location on the east side is north of the point
+	meters := geoDistanceRect(P(25, 15), rect)
+	expect(t, meters < geoDistancePoints(P(25, 15), P(20, 15)))
+	expect(t, meters > 0)
+	for i := 0; i < 100; i++ {
+		for _, point := range []geometry.Point{
+			P(10+float64(i)/10, 10), P(10+float64(i)/10, 20),
+			P(20, 10+float64(i)/10),
+		} {
+			expect(t, geoDistancePoints(P(25, 15), point) >= meters)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package geojson
+
+import (
+	"math"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+// Nearest is the nearest location on an object to another location.
+type Nearest struct {
+	geometry.Nearest
+	Child  int     // the child index for collections, otherwise 0
+	Meters float64 // the distance in meters to the other location
+}
+
+// nearestPartPoint returns the nearest location on a part to a point.
+func nearestPartPoint(part objectPart, point geometry.Point,
+) (geometry.Nearest, float64) {
+	switch {
+	case part.line != nil:
+		index, meters := nearestSeries(point, part.line)
+		return nearestOnSeries(point, part.line, index), meters
+	case part.poly != nil:
+		if part.poly.IntersectsPoint(point) {
+			return geometry.Nearest{Point: point, Index: -1}, 0
+		}
+		var nearest geometry.Nearest
+		best := math.Inf(+1)
+		for i, ring := range polyRings(part.poly) {
+			if index, meters := nearestSeries(point, ring); meters < best {
+				best = meters
+				nearest = nearestOnSeries(point, ring, index)
+				nearest.Ring = i
+			}
+		}
+		return nearest, best
+	}
+	return geometry.Nearest{Point: part.point, Index: -1},
+		geoDistancePoints(part.point, point)
+}
+
+// pairParts returns the nearest locations between two parts.
+func pairParts(a, b objectPart) (na, nb geometry.Nearest, meters float64) {
+	switch {
+	case a.line == nil && a.poly == nil:
+		nb, meters = nearestPartPoint(b, a.point)
+		return geometry.Nearest{Point: a.point, Index: -1}, nb, meters
+	case b.line == nil && b.poly == nil:
+		na, meters = nearestPartPoint(a, b.point)
+		return na, geometry.Nearest{Point: b.point, Index: -1}, meters
+	case a.line != nil && b.line != nil:
+		if a.line.IntersectsLine(b.line) {
+			na, nb = a.line.ClosestPairLine(b.line)
+			return na, nb, 0
+		}
+		return pairSeries(a.line, b.line)
+	case a.line != nil:
+		nb, na, meters = pairPolyLine(b.poly, a.line)
+		return na, nb, meters
+	case b.line != nil:
+		return pairPolyLine(a.poly, b.line)
+	}
+	if a.poly.IntersectsPoly(b.poly) {
+		na, nb = a.poly.ClosestPairPoly(b.poly)
+		return na, nb, 0
+	}
+	meters = math.Inf(+1)
+	for i, ringA := range polyRings(a.poly) {
+		for j, ringB := range polyRings(b.poly) {
+			ra, rb, dist := pairSeries(ringA, ringB)
+			if dist < meters {
+				ra.Ring, rb.Ring = i, j
+				na, nb, meters = ra, rb, dist
+			}
+		}
+	}
+	return na, nb, meters
+}
+
+func pairPolyLine(poly *geometry.Poly, line *geometry.Line,
+) (na, nb geometry.Nearest, meters float64) {
+	if poly.IntersectsLine(line) {
+		na, nb = poly.ClosestPairLine(line)
+		return na, nb, 0
+	}
+	meters = math.Inf(+1)
+	for i, ring := range polyRings(poly) {
+		ra, rb, dist := pairSeries(ring, line)
+		if dist < meters {
+			ra.Ring = i
+			na, nb, meters = ra, rb, dist
+		}
+	}
+	return na, nb, meters
+}
+
+// nearestPoint returns the nearest location on an object to a point. The
+// center of an empty object is used as its location.
+func nearestPoint(obj Object, point geometry.Point) Nearest {
+	parts := appendObjectParts(nil, obj, 0, true)
+	if len(parts) == 0 {
+		center := obj.Center()
+		return Nearest{
+			Nearest: geometry.Nearest{Point: center, Index: -1},
+			Meters:  geoDistancePoints(center, point),
+		}
+	}
+	nearest := Nearest{Meters: math.Inf(+1)}
+	for _, part := range parts {
+		near, meters := nearestPartPoint(part, point)
+		if meters < nearest.Meters {
+			nearest = Nearest{Nearest: near, Child: part.child, Meters: meters}
+			if meters == 0 {
+				break
+			}
+		}
+	}
+	return nearest
+}
+
+// closestPair returns the nearest locations between two objects. The center
+// of an empty object is used as its location.
+func closestPair(obj, other Object) (a, b Nearest) {
+	partsA := appendObjectParts(nil, obj, 0, true)
+	partsB := appendObjectParts(nil, other, 0, true)
+	if len(partsA) == 0 || len(partsB) == 0 {
+		ca, cb := obj.Center(), other.Center()
+		meters := geoDistancePoints(ca, cb)
+		return Nearest{geometry.Nearest{Point: ca, Index: -1}, 0, meters},
+			Nearest{geometry.Nearest{Point: cb, Index: -1}, 0, meters}
+	}
+	a.Meters = math.Inf(+1)
+	for _, partA := range partsA {
+		for _, partB := range partsB {
+			na, nb, meters := pairParts(partA, partB)
+			if meters < a.Meters {
+				a = Nearest{Nearest: na, Child: partA.child, Meters: meters}
+				b = Nearest{Nearest: nb, Child: partB.child, Meters: meters}
+				if meters == 0 {
+					return a, b
+				}
+			}
+		}
+	}
+	return a, b
+}
@@ -0,0 +1,100 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func expectNearest(t *testing.T, n Nearest, point geometry.Point, child,
+	ring, index int,
+) {
+	t.Helper()
+	if !(n.Child == child && n.Ring == ring && n.Index == index &&
+		geoDistancePoints(n.Point, point) < 0.001) {
+		t.Fatalf("expected '%v %v %v %v', got '%v %v %v %v'",
+			point, child, ring, index, n.Point, n.Child, n.Ring, n.Index)
+	}
+}
+
+func TestNearestPoint(t *testing.T) {
+	poly := expectJSON(t,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`,
+		nil)
+	// the nearest point is as far away as the distance
+	for _, point := range []geometry.Point{P(5, -1), P(11, 5), P(5, 5),
+		P(1, 5), P(-3, -4)} {
+		n := poly.NearestPoint(point)
+		expectMeters(t, n.Meters, poly.Distance(PO(point.X, point.Y)))
+		expectMeters(t, geoDistancePoints(n.Point, point), n.Meters)
+	}
+	expectNearest(t, poly.NearestPoint(P(1, 5)), P(1, 5), 0, 0, -1)
+	expectNearest(t, poly.NearestPoint(P(-3, -4)), P(0, 0), 0, 0, 0)
+	expectNearest(t, poly.NearestPoint(P(5, -1)), P(5, 0), 0, 0, 0)
+	expect(t, poly.NearestPoint(P(5, 5)).Ring == 1)
+
+	line := LO([]geometry.Point{P(0, 0), P(0, 10), P(10, 10)})
+	expectNearest(t, line.NearestPoint(P(0, 5)), P(0, 5), 0, 0, 0)
+	expectNearest(t, line.NearestPoint(P(12, 12)), P(10, 10), 0, 0, 1)
+	expectNearest(t, PO(1, 2).NearestPoint(P(3, 4)), P(1, 2), 0, 0, -1)
+	expectNearest(t, RO(0, 0, 10, 10).NearestPoint(P(5, -1)), P(5, 0), 0, 0, 0)
+
+	// collections report the child
+	fc := expectJSON(t, `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[20,0]},"properties":{}},
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[-1,-5],[-1,0]]},"properties":{}}
+	]}`, nil)
+	expectNearest(t, fc.NearestPoint(P(-1, -6)), P(-1, -5), 1, 0, 0)
+	expectNearest(t, fc.NearestPoint(P(19, 0)), P(20, 0), 0, 0, -1)
+	mp := expectJSON(t, `{"type":"MultiPoint","coordinates":[[20,0],[11,0]]}`, nil)
+	expectNearest(t, mp.NearestPoint(P(12, 0)), P(11, 0), 1, 0, -1)
+
+	// empty objects use their center
+	n := expectJSON(t, `{"type":"MultiPoint","coordinates":[]}`, nil).
+		NearestPoint(P(1, 0))
+	expect(t, n.Index == -1)
+}
+
+func TestClosestPair(t *testing.T) {
+	poly := expectJSON(t,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`,
+		nil)
+	objs := []Object{
+		PO(5, -1), PO(5, 1),
+		LO([]geometry.Point{P(11, -5), P(11, 0)}),
+		LO([]geometry.Point{P(-5, 5), P(5, 5)}),
+		LO([]geometry.Point{P(4, 4), P(5, 5)}),
+		RO(11, 0, 12, 10), RO(3, 3, 4, 4), RO(-1, -1, 11, 11),
+		expectJSON(t, `{"type":"MultiPoint","coordinates":[[20,0],[11,0]]}`, nil),
+	}
+	// the pair is as far apart as the distance, both ways around
+	for _, obj := range objs {
+		a, b := poly.ClosestPair(obj)
+		expectMeters(t, a.Meters, poly.Distance(obj))
+		expectMeters(t, geoDistancePoints(a.Point, b.Point), a.Meters)
+		b2, a2 := obj.ClosestPair(poly)
+		expectMeters(t, a2.Meters, a.Meters)
+		expectMeters(t, geoDistancePoints(a2.Point, b2.Point), a.Meters)
+	}
+
+	// crossing lines meet at the intersection
+	a, b := LO([]geometry.Point{P(0, 0), P(10, 10)}).
+		ClosestPair(LO([]geometry.Point{P(0, 10), P(10, 0)}))
+	expect(t, a.Meters == 0 && a.Point == P(5, 5) && b.Point == P(5, 5))
+
+	// where the vehicle enters the zone
+	a, b = poly.ClosestPair(LO([]geometry.Point{P(5, -5), P(5, -1), P(5, 1)}))
+	expectNearest(t, a, P(5, 0), 0, 0, 0)
+	expectNearest(t, b, P(5, 0), 0, 0, 1)
+
+	// nearest vertex to segment
+	a, b = poly.ClosestPair(LO([]geometry.Point{P(11, -5), P(11, 0)}))
+	expectNearest(t, a, P(10, 0), 0, 0, 1)
+	expectNearest(t, b, P(11, 0), 0, 0, 0)
+
+	// collections report the child
+	mp := expectJSON(t, `{"type":"MultiPoint","coordinates":[[20,0],[11,0]]}`, nil)
+	a, b = poly.ClosestPair(mp)
+	expectNearest(t, b, P(11, 0), 1, 0, -1)
+	expect(t, a.Point == P(10, 0))
+}
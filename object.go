@@ -25,6 +25,7 @@ var (
 	errGeometriesMissing        = errors.New("missing geometries")
 	errGeometriesInvalid        = errors.New("invalid geometries")
 	errCircleRadiusUnitsInvalid = errors.New("invalid circle radius units")
+	errBinaryInvalid            = errors.New("invalid binary data")
 )
 
 // Object is a GeoJSON type
@@ -33,17 +34,91 @@ type Object interface {
 	Valid() bool
 	Rect() geometry.Rect
 	Center() geometry.Point
+	// Centroid returns the centroid of the highest dimension parts of the
+	// object. Polygons are weighted by area, lines by length, and points are
+	// averaged.
+	Centroid() geometry.Point
+	// PointOnSurface returns a point that is guaranteed to be on the object,
+	// such as inside of a polygon, or a vertex of a line.
+	PointOnSurface() geometry.Point
+	// PoleOfInaccessibility returns the point inside of the object's polygons
+	// that is farthest from their edges, to within precision coordinate
+	// units. This is the best place for a label. An object without polygons
+	// uses its PointOnSurface.
+	PoleOfInaccessibility(precision float64) geometry.Point
 	Contains(other Object) bool
 	Within(other Object) bool
 	Intersects(other Object) bool
+	// Relate returns the DE-9IM intersection matrix between the object and
+	// another object, which can be tested with RelateMatch.
+	Relate(other Object) string
+	// Equals returns true if the object and another object are topologically
+	// equal.
+	Equals(other Object) bool
+	// Disjoint returns true if the object and another object have no points
+	// in common.
+	Disjoint(other Object) bool
+	// Touches returns true if the object and another object have a point in
+	// common, but their interiors do not intersect.
+	Touches(other Object) bool
+	// Crosses returns true if the interiors of the object and another object
+	// intersect in a lower dimension than at least one of them.
+	Crosses(other Object) bool
+	// Overlaps returns true if the object and another object have the same
+	// dimension and share some, but not all, of their interiors.
+	Overlaps(other Object) bool
+	// Covers returns true if no point of another object is outside of the
+	// object.
+	Covers(other Object) bool
+	// CoveredBy returns true if no point of the object is outside of another
+	// object.
+	CoveredBy(other Object) bool
+	// Buffer returns the area within a distance in meters of the object, as a
+	// Polygon or a MultiPolygon. A negative distance shrinks polygons. A nil
+	// opts uses the geometry.DefaultBufferOptions.
+	Buffer(meters float64, opts *geometry.BufferOptions) Object
+	// ConvexHull returns the smallest convex Polygon that contains the object.
+	// The hull is a LineString when all of the points are on a line, and a
+	// Point when all of the points are the same.
+	ConvexHull() Object
+	// ConcaveHull returns a Polygon that contains the object and follows its
+	// shape more closely than the convex hull, where a ratio of 1 is the
+	// convex hull and 0 is the closest fit.
+	ConcaveHull(ratio float64) Object
 	AppendJSON(dst []byte) []byte
 	JSON() string
+	// AppendWKT appends the Well-Known Text representation to dst. A Circle
+	// is written as its polygon, a Feature as its geometry, and both the
+	// GeometryCollection and FeatureCollection as a GEOMETRYCOLLECTION.
+	AppendWKT(dst []byte) []byte
+	// WKT returns the Well-Known Text representation.
+	WKT() string
+	// AppendWKB appends the little-endian Well-Known Binary representation to
+	// dst. A Circle is written as its polygon, a Feature as its geometry, and
+	// both the GeometryCollection and FeatureCollection as a
+	// GeometryCollection.
+	AppendWKB(dst []byte) []byte
+	// AppendEWKB appends the little-endian PostGIS Extended Well-Known Binary
+	// representation to dst, which is the Well-Known Binary with an srid. The
+	// srid is omitted when zero.
+	AppendEWKB(dst []byte, srid int) []byte
 	String() string
 	Distance(obj Object) float64
+	// NearestPoint returns the nearest location on the object to a point,
+	// with its geodesic distance in meters. The center of an empty object is
+	// used as its location.
+	NearestPoint(point geometry.Point) Nearest
+	// ClosestPair returns the nearest locations between the object and
+	// another object, where a is on the object and b is on the other object.
+	ClosestPair(obj Object) (a, b Nearest)
 	NumPoints() int
 	ForEach(iter func(geom Object) bool) bool
 	Spatial() Spatial
 	MarshalJSON() ([]byte, error)
+	// MarshalBinary returns the native binary representation, which includes
+	// the prebuilt geometry segment indexes, so that they aren't built again
+	// when the object is loaded by the UnmarshalBinary of its type.
+	MarshalBinary() ([]byte, error)
 	Members() string
 }
 
@@ -59,6 +134,10 @@ type Collection interface {
 	Children() []Object
 	Indexed() bool
 	Search(rect geometry.Rect, iter func(child Object) bool)
+	Nearby(point geometry.Point, k int, maxMeters float64,
+		iter func(child Object, meters float64) bool)
+	SearchIntersects(obj Object, iter func(child Object) bool)
+	SearchWithin(obj Object, iter func(child Object) bool)
 }
 
 var _ = []Collection{
@@ -68,6 +147,7 @@ var _ = []Collection{
 
 type extra struct {
 	dims   byte      // number of extra coordinate values, 1 or 2
+	m      bool      // the one extra coordinate value is M rather than Z
 	values []float64 // extra coordinate values
 	// valid json object that includes extra members such as
 	// "bbox", "id", "properties", and foreign members
@@ -103,6 +183,16 @@ type ParseOptions struct {
 	// exactly 5 points with the first point being the min x/y and the
 	// following point winding counter clockwise creating a closed rectangle.
 	AllowRects bool
+	// SplitAntimeridian option will cause lines and polygons that cross the
+	// antimeridian, by jumping more than 180 degrees of longitude from one
+	// point to the next, to be split into a MultiLineString or MultiPolygon
+	// with parts that meet at ±180, as described in RFC 7946 section 3.1.9.
+	// Extra coordinates, such as Z, are interpolated where the parts meet.
+	SplitAntimeridian bool
+	// CircleOptions are the options for the Circle objects that are made
+	// from the special Circle syntax. A nil value uses the
+	// DefaultCircleOptions.
+	CircleOptions *CircleOptions
 }
 
 var DefaultParseOptions = &ParseOptions{
@@ -113,14 +203,25 @@ var DefaultParseOptions = &ParseOptions{
 	AllowSimplePoints: false,
 	DisableCircleType: false,
 	AllowRects:        false,
+	SplitAntimeridian: false,
+	CircleOptions:     nil,
 }
 
-// Parse a GeoJSON object
+// Parse a GeoJSON object, a Well-Known Text (WKT) geometry, or a Well-Known
+// Binary (WKB/EWKB) geometry. WKB may also be provided as a hex string.
 func Parse(data string, opts *ParseOptions) (Object, error) {
 	if opts == nil {
 		// opts should never be nil
 		opts = DefaultParseOptions
 	}
+	obj, err := parse(data, opts)
+	if err != nil || !opts.SplitAntimeridian {
+		return obj, err
+	}
+	return splitAntimeridian(obj, opts), nil
+}
+
+func parse(data string, opts *ParseOptions) (Object, error) {
 	// look at the first byte
 	for i := 0; ; i++ {
 		if len(data) == 0 {
@@ -128,15 +229,16 @@ func Parse(data string, opts *ParseOptions) (Object, error) {
 		}
 		switch data[0] {
 		default:
-			// well-known text is not supported yet
-			return nil, errDataInvalid
+			if isHexWKB(data) {
+				return parseHexWKB(data, opts)
+			}
+			return parseWKT(data, opts)
 		case 0, 1:
 			if i > 0 {
 				// 0x00 or 0x01 must be the first bytes
 				return nil, errDataInvalid
 			}
-			// well-known binary is not supported yet
-			return nil, errDataInvalid
+			return parseWKB(data, opts)
 		case ' ', '\t', '\n', '\r':
 			// strip whitespace
 			data = data[1:]
@@ -316,3 +418,50 @@ func unionRects(a, b geometry.Rect) geometry.Rect {
 func geoDistancePoints(a, b geometry.Point) float64 {
 	return geo.DistanceTo(a.Y, a.X, b.Y, b.X)
 }
+
+// objectPart is a point, line or polygon that makes up an object.
+type objectPart struct {
+	child int
+	point geometry.Point
+	line  *geometry.Line
+	poly  *geometry.Poly
+}
+
+// appendObjectParts appends the non-empty points, lines and polygons that
+// make up an object. Members of a collection use the index of the top level
+// child.
+func appendObjectParts(parts []objectPart, obj Object, child int,
+	top bool,
+) []objectPart {
+	if obj.Empty() {
+		return parts
+	}
+	switch g := obj.(type) {
+	case *Point:
+		parts = append(parts, objectPart{child: child, point: g.base})
+	case *SimplePoint:
+		parts = append(parts, objectPart{child: child, point: g.Point})
+	case *LineString:
+		parts = append(parts, objectPart{child: child, line: &g.base})
+	case *Polygon:
+		parts = append(parts, objectPart{child: child, poly: &g.base})
+	case *Rect:
+		parts = append(parts, objectPart{child: child, poly: rectPoly(g.base)})
+	case *Circle:
+		parts = appendObjectParts(parts, g.getObject(), child, false)
+	case *Feature:
+		parts = appendObjectParts(parts, g.base, child, false)
+	case Collection:
+		for i, member := range g.Children() {
+			if top {
+				child = i
+			}
+			parts = appendObjectParts(parts, member, child, false)
+		}
+	}
+	return parts
+}
+
+func polyRings(poly *geometry.Poly) []geometry.Ring {
+	return append([]geometry.Ring{poly.Exterior}, poly.Holes...)
+}
@@ -0,0 +1,26 @@
+package geojson
+
+import "github.com/tidwall/geojson/geometry"
+
+// objectPolys returns the polygons of an object. Points and lines have no
+// area and are ignored.
+func objectPolys(obj Object) []*geometry.Poly {
+	var polys []*geometry.Poly
+	for _, part := range appendObjectParts(nil, obj, 0, true) {
+		if part.poly != nil {
+			polys = append(polys, part.poly)
+		}
+	}
+	return polys
+}
+
+// overlay performs a boolean operation on the polygons of two objects. The
+// result is a Polygon when there is exactly one polygon, otherwise it's a
+// MultiPolygon, which is empty when there is no remaining area.
+func overlay(obj, other Object, op geometry.OverlayOp) Object {
+	polys := geometry.Overlay(objectPolys(obj), objectPolys(other), op)
+	if len(polys) == 1 {
+		return NewPolygon(polys[0])
+	}
+	return NewMultiPolygon(polys)
+}
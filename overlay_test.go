@@ -0,0 +1,50 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestOverlay(t *testing.T) {
+	a, b := RO(0, 0, 10, 10), RO(5, 5, 15, 15)
+	union := a.Union(b)
+	expect(t, union.(*Polygon).Area() == 175)
+	expect(t, a.Intersection(b).Rect() == R(5, 5, 10, 10))
+	expect(t, a.Intersection(b).(*Polygon).Area() == 25)
+	expect(t, a.Difference(b).(*Polygon).Area() == 75)
+	xor := a.SymmetricDifference(b).(*MultiPolygon)
+	expect(t, len(xor.Children()) == 2 && xor.Area() == 150)
+
+	// no remaining area is an empty multipolygon
+	none := a.Intersection(RO(20, 20, 30, 30))
+	expect(t, none.Empty() && none.JSON() ==
+		`{"type":"MultiPolygon","coordinates":[]}`)
+
+	// merging adjacent zones
+	zones := expectJSON(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[10,0],[10,10],[0,10],[0,0]]],
+		[[[20,0],[30,0],[30,10],[20,10],[20,0]]]]}`, nil).(*MultiPolygon)
+	merged := zones.Union(RO(10, 0, 20, 10))
+	expect(t, merged.JSON() ==
+		`{"type":"Polygon","coordinates":[[[0,0],[30,0],[30,10],[0,10],[0,0]]]}`)
+
+	// clipping a polygon with a hole
+	holed := expectJSON(t, `{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]],
+		[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`, nil).(*Polygon)
+	clipped := holed.Intersection(RO(0, 0, 5, 10)).(*Polygon)
+	expect(t, clipped.Area() == 32)
+	expect(t, holed.Union(RO(2, 2, 8, 8)).(*Polygon).Area() == 100)
+
+	// points and lines have no area
+	expect(t, a.Difference(PO(5, 5)).(*Polygon).Area() == 100)
+	expect(t, a.Intersection(
+		LO([]geometry.Point{P(0, 0), P(10, 10)})).Empty())
+
+	// circles use their polygons
+	circle := NewCircle(P(0, 0), 10000, 64)
+	diff := circle.Difference(RO(0, 0, 1, 1)).(*Polygon)
+	expect(t, diff.Area() < circle.Area() && diff.Area() > circle.Area()/2)
+	expect(t, !diff.Contains(PO(0.01, 0.01)) && diff.Contains(PO(0.01, -0.01)))
+}
@@ -59,6 +59,26 @@ func (g *Point) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *Point) AppendWKT(dst []byte) []byte {
+	return appendWKTTagged(dst, "POINT", g)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *Point) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *Point) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *Point) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *Point) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -67,6 +87,21 @@ func (g *Point) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *Point) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *Point) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binPoint)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*Point)
+	return nil
+}
+
 func (g *Point) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -123,7 +158,7 @@ func (g *Point) NumPoints() int {
 }
 
 func (g *Point) Z() float64 {
-	if g.extra != nil && len(g.extra.values) > 0 {
+	if g.extra != nil && !g.extra.m && len(g.extra.values) > 0 {
 		return g.extra.values[0]
 	}
 	return 0
@@ -156,6 +191,15 @@ func parseJSONPoint(keys *parseKeys, opts *ParseOptions) (Object, error) {
 	return o, nil
 }
 
+// makePointObject returns a Point, or a SimplePoint when allowed by the
+// options.
+func makePointObject(base geometry.Point, ex *extra, opts *ParseOptions) Object {
+	if ex == nil && opts.AllowSimplePoints {
+		return &SimplePoint{Point: base}
+	}
+	return &Point{base: base, extra: ex}
+}
+
 func parseJSONPointCoords(
 	keys *parseKeys, rcoords gjson.Result, opts *ParseOptions,
 ) (geometry.Point, *extra, error) {
@@ -213,24 +257,105 @@ func parseJSONPointCoords(
 	return coords, ex, nil
 }
 
+// Centroid returns the point.
+func (g *Point) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns the point.
+func (g *Point) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the point.
+func (g *Point) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *Point) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *Point) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *Point) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *Point) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *Point) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *Point) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *Point) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *Point) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the point.
+func (g *Point) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the point.
+func (g *Point) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the point.
+func (g *Point) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the point to a point.
+func (g *Point) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the point and obj.
+func (g *Point) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *Point) Distance(obj Object) float64 {
 	return obj.Spatial().DistancePoint(g.base)
 }
 
 func (g *Point) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return geoDistancePoints(g.base, point)
 }
 
 func (g *Point) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return distPointPoly(g.base, rectPoly(rect))
 }
 
 func (g *Point) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return distPointLine(g.base, line)
 }
 
 func (g *Point) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return distPointPoly(g.base, poly)
 }
 
 // IsSimple returns true if the Point can be converted to a SimplePoint
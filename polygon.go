@@ -56,6 +56,26 @@ func (g *Polygon) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *Polygon) AppendWKT(dst []byte) []byte {
+	return appendWKTTagged(dst, "POLYGON", g)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *Polygon) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *Polygon) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *Polygon) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *Polygon) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -64,6 +84,65 @@ func (g *Polygon) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *Polygon) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *Polygon) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binPolygon)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*Polygon)
+	return nil
+}
+
+// Area returns the planar area in square coordinate units, with the area of
+// the holes subtracted.
+func (g *Polygon) Area() float64 {
+	return g.base.Area()
+}
+
+// GeodesicArea returns the area in square meters on the WGS84 ellipsoid,
+// with the area of the holes subtracted.
+func (g *Polygon) GeodesicArea() float64 {
+	return geodesicPolyArea(&g.base)
+}
+
+// Union returns the area that is in either the object or another object.
+func (g *Polygon) Union(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayUnion)
+}
+
+// Intersection returns the area that is in both the object and another
+// object.
+func (g *Polygon) Intersection(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayIntersection)
+}
+
+// Difference returns the area of the object that is not in another object.
+func (g *Polygon) Difference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayDifference)
+}
+
+// SymmetricDifference returns the area that is in only one of the object and
+// another object.
+func (g *Polygon) SymmetricDifference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlaySymmetricDifference)
+}
+
+// Length returns the planar perimeter, including holes, in coordinate units.
+func (g *Polygon) Length() float64 {
+	return g.base.Length()
+}
+
+// GeodesicLength returns the perimeter, including holes, in meters on the WGS84 ellipsoid.
+func (g *Polygon) GeodesicLength() float64 {
+	return geodesicPolyLength(&g.base)
+}
+
 func (g *Polygon) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -101,6 +180,9 @@ func (g *Polygon) WithinPoly(poly *geometry.Poly) bool {
 }
 
 func (g *Polygon) Intersects(obj Object) bool {
+	if obj, ok := obj.(*Circle); ok {
+		return obj.Intersects(g)
+	}
 	return obj.Spatial().IntersectsPoly(&g.base)
 }
 
@@ -151,16 +233,7 @@ func parseJSONPolygon(keys *parseKeys, opts *ParseOptions) (Object, error) {
 	if err := parseBBoxAndExtras(&extra, keys, opts); err != nil {
 		return nil, err
 	}
-	if extra == nil && opts.AllowRects &&
-		len(holes) == 0 && len(exterior) == 5 &&
-		exterior[0].X < exterior[1].X &&
-		exterior[0].Y == exterior[1].Y &&
-		exterior[1].X == exterior[2].X &&
-		exterior[1].Y < exterior[2].Y &&
-		exterior[2].X > exterior[3].X &&
-		exterior[2].Y == exterior[3].Y &&
-		exterior[3].X == exterior[4].X &&
-		exterior[3].Y > exterior[4].Y {
+	if extra == nil && opts.AllowRects && isRectPolygon(exterior, holes) {
 		// simple rectangle
 		o = NewRect(geometry.Rect{
 			Min: exterior[0],
@@ -181,6 +254,46 @@ func parseJSONPolygon(keys *parseKeys, opts *ParseOptions) (Object, error) {
 	return o, nil
 }
 
+// makePolygonObject returns a Polygon from already parsed rings, or a Rect
+// when allowed by the options. The first ring is the exterior.
+func makePolygonObject(
+	rings [][]geometry.Point, ex *extra, opts *ParseOptions,
+) (Object, error) {
+	for _, p := range rings {
+		if len(p) < 4 || p[0] != p[len(p)-1] {
+			return nil, errCoordinatesInvalid // must be a linear ring
+		}
+	}
+	var exterior []geometry.Point
+	var holes [][]geometry.Point
+	if len(rings) > 0 {
+		exterior = rings[0]
+		holes = rings[1:]
+	}
+	if ex == nil && opts.AllowRects && isRectPolygon(exterior, holes) {
+		return NewRect(geometry.Rect{Min: exterior[0], Max: exterior[2]}), nil
+	}
+	gopts := toGeometryOpts(opts)
+	return &Polygon{
+		base:  *geometry.NewPoly(exterior, holes, &gopts),
+		extra: ex,
+	}, nil
+}
+
+// isRectPolygon returns true when the exterior is a perfect rectangle that
+// starts at the min x/y and winds counter clockwise, and there are no holes.
+func isRectPolygon(exterior []geometry.Point, holes [][]geometry.Point) bool {
+	return len(holes) == 0 && len(exterior) == 5 &&
+		exterior[0].X < exterior[1].X &&
+		exterior[0].Y == exterior[1].Y &&
+		exterior[1].X == exterior[2].X &&
+		exterior[1].Y < exterior[2].Y &&
+		exterior[2].X > exterior[3].X &&
+		exterior[2].Y == exterior[3].Y &&
+		exterior[3].X == exterior[4].X &&
+		exterior[3].Y > exterior[4].Y
+}
+
 func parseJSONPolygonCoords(
 	keys *parseKeys, rcoords gjson.Result, opts *ParseOptions,
 ) (
@@ -259,24 +372,106 @@ func parseJSONPolygonCoords(
 	return coords, ex, err
 }
 
+// Centroid returns the centroid of the polygon, weighted by area.
+func (g *Polygon) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns a point inside of the polygon.
+func (g *Polygon) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the point inside of the polygon that is
+// farthest from its edges.
+func (g *Polygon) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *Polygon) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *Polygon) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *Polygon) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *Polygon) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *Polygon) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *Polygon) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *Polygon) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *Polygon) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the polygon.
+func (g *Polygon) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the polygon.
+func (g *Polygon) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the polygon.
+func (g *Polygon) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the polygon to a point.
+func (g *Polygon) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the polygon and obj.
+func (g *Polygon) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *Polygon) Distance(obj Object) float64 {
 	return obj.Spatial().DistancePoly(&g.base)
 }
 
 func (g *Polygon) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return distPointPoly(point, &g.base)
 }
 
 func (g *Polygon) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return distPolyPoly(&g.base, rectPoly(rect))
 }
 
 func (g *Polygon) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return distLinePoly(line, &g.base)
 }
 
 func (g *Polygon) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return distPolyPoly(&g.base, poly)
 }
 
 func (g *Polygon) HasExtra() bool {
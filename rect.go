@@ -47,6 +47,26 @@ func (g *Rect) AppendJSON(dst []byte) []byte {
 	return g.Polygon().AppendJSON(dst)
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *Rect) AppendWKT(dst []byte) []byte {
+	return appendWKTTagged(dst, "POLYGON", g)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *Rect) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *Rect) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *Rect) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *Rect) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -55,6 +75,63 @@ func (g *Rect) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *Rect) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *Rect) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binRect)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*Rect)
+	return nil
+}
+
+// Area returns the planar area in square coordinate units.
+func (g *Rect) Area() float64 {
+	return g.base.Area()
+}
+
+// GeodesicArea returns the area in square meters on the WGS84 ellipsoid.
+func (g *Rect) GeodesicArea() float64 {
+	return geodesicSeriesArea(g.base)
+}
+
+// Union returns the area that is in either the object or another object.
+func (g *Rect) Union(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayUnion)
+}
+
+// Intersection returns the area that is in both the object and another
+// object.
+func (g *Rect) Intersection(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayIntersection)
+}
+
+// Difference returns the area of the object that is not in another object.
+func (g *Rect) Difference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlayDifference)
+}
+
+// SymmetricDifference returns the area that is in only one of the object and
+// another object.
+func (g *Rect) SymmetricDifference(obj Object) Object {
+	return overlay(g, obj, geometry.OverlaySymmetricDifference)
+}
+
+// Length returns the planar perimeter in coordinate units.
+func (g *Rect) Length() float64 {
+	return rectPoly(g.base).Length()
+}
+
+// GeodesicLength returns the perimeter in meters on the WGS84 ellipsoid.
+func (g *Rect) GeodesicLength() float64 {
+	return geodesicSeriesLength(g.base)
+}
+
 func (g *Rect) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -84,6 +161,9 @@ func (g *Rect) WithinPoly(poly *geometry.Poly) bool {
 }
 
 func (g *Rect) Intersects(obj Object) bool {
+	if obj, ok := obj.(*Circle); ok {
+		return obj.Intersects(g)
+	}
 	return obj.Spatial().IntersectsRect(g.base)
 }
 
@@ -111,24 +191,106 @@ func (g *Rect) Spatial() Spatial {
 	return g
 }
 
+// Centroid returns the center of the rectangle.
+func (g *Rect) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns the center of the rectangle.
+func (g *Rect) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the center of the rectangle, to within
+// precision.
+func (g *Rect) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *Rect) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *Rect) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *Rect) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *Rect) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *Rect) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *Rect) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *Rect) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *Rect) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the rectangle.
+func (g *Rect) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the rectangle.
+func (g *Rect) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the rectangle.
+func (g *Rect) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the rectangle to a point.
+func (g *Rect) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the rectangle and obj.
+func (g *Rect) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *Rect) Distance(obj Object) float64 {
 	return obj.Spatial().DistanceRect(g.base)
 }
 
 func (g *Rect) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return distPointPoly(point, rectPoly(g.base))
 }
 
 func (g *Rect) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return distPolyPoly(rectPoly(g.base), rectPoly(rect))
 }
 
 func (g *Rect) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return distLinePoly(line, rectPoly(g.base))
 }
 
 func (g *Rect) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return distPolyPoly(rectPoly(g.base), poly)
 }
 
 func (g *Rect) Members() string {
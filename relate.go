@@ -0,0 +1,136 @@
+package geojson
+
+import "github.com/tidwall/geojson/geometry"
+
+// relateComposite returns the parts of an object as a single geometry, where
+// the parts of a collection are joined together.
+func relateComposite(obj Object) *geometry.Composite {
+	var comp geometry.Composite
+	for _, part := range appendObjectParts(nil, obj, 0, true) {
+		switch {
+		case part.poly != nil:
+			comp.Polys = append(comp.Polys, part.poly)
+		case part.line != nil:
+			comp.Lines = append(comp.Lines, part.line)
+		default:
+			comp.Points = append(comp.Points, part.point)
+		}
+	}
+	return &comp
+}
+
+// relate returns the DE-9IM matrix between two objects and their dimensions.
+func relate(obj, other Object) (matrix string, dimA, dimB int) {
+	a, b := relateComposite(obj), relateComposite(other)
+	return a.Relate(b), a.Dimension(), b.Dimension()
+}
+
+// RelateMatch returns true if a DE-9IM matrix, such as "212101212", matches a
+// pattern, such as "T*F**F***". Each pattern character is matched against the
+// matrix character at the same position, where 'T' matches any intersection,
+// 'F' matches no intersection, '0', '1' and '2' match an intersection of that
+// dimension, and '*' matches anything.
+func RelateMatch(matrix, pattern string) bool {
+	if len(matrix) != 9 || len(pattern) != 9 {
+		return false
+	}
+	for i := 0; i < 9; i++ {
+		m, p := matrix[i], pattern[i]
+		switch p {
+		case '*':
+		case 'T', 't':
+			if m == 'F' {
+				return false
+			}
+		case 'F', 'f':
+			if m != 'F' {
+				return false
+			}
+		default:
+			if m != p {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// relateMatchAny returns true if a DE-9IM matrix matches any of the patterns.
+func relateMatchAny(matrix string, patterns ...string) bool {
+	for _, pattern := range patterns {
+		if RelateMatch(matrix, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// equals returns true if the objects are topologically equal.
+func equals(obj, other Object) bool {
+	matrix, _, _ := relate(obj, other)
+	return RelateMatch(matrix, "T*F**FFF*")
+}
+
+// disjoint returns true if the objects have no points in common.
+func disjoint(obj, other Object) bool {
+	matrix, _, _ := relate(obj, other)
+	return RelateMatch(matrix, "FF*FF****")
+}
+
+// touches returns true if the objects have at least one point in common, but
+// their interiors do not intersect.
+func touches(obj, other Object) bool {
+	matrix, dimA, dimB := relate(obj, other)
+	if dimA == 0 && dimB == 0 {
+		return false
+	}
+	return relateMatchAny(matrix, "FT*******", "F**T*****", "F***T****")
+}
+
+// crosses returns true if the objects have some but not all interior points
+// in common, and the dimension of the intersection is less than that of at
+// least one of the objects.
+func crosses(obj, other Object) bool {
+	matrix, dimA, dimB := relate(obj, other)
+	switch {
+	case dimA < dimB:
+		return RelateMatch(matrix, "T*T******")
+	case dimA > dimB:
+		return RelateMatch(matrix, "T*****T**")
+	case dimA == 1:
+		return RelateMatch(matrix, "0********")
+	}
+	return false
+}
+
+// overlaps returns true if the objects have the same dimension, have some
+// but not all points in common, and their intersection has the same
+// dimension.
+func overlaps(obj, other Object) bool {
+	matrix, dimA, dimB := relate(obj, other)
+	switch {
+	case dimA != dimB:
+		return false
+	case dimA == 1:
+		return RelateMatch(matrix, "1*T***T**")
+	case dimA >= 0:
+		return RelateMatch(matrix, "T*T***T**")
+	}
+	return false
+}
+
+// covers returns true if no point of the other object is outside of the
+// object.
+func covers(obj, other Object) bool {
+	matrix, _, _ := relate(obj, other)
+	return relateMatchAny(matrix,
+		"T*****FF*", "*T****FF*", "***T**FF*", "****T*FF*")
+}
+
+// coveredBy returns true if no point of the object is outside of the other
+// object.
+func coveredBy(obj, other Object) bool {
+	matrix, _, _ := relate(obj, other)
+	return relateMatchAny(matrix,
+		"T*F**F***", "*TF**F***", "**FT*F***", "**F*TF***")
+}
@@ -0,0 +1,76 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestRelateMatch(t *testing.T) {
+	expect(t, RelateMatch("212101212", "T*T***T**"))
+	expect(t, RelateMatch("212101212", "2*2***2**"))
+	expect(t, !RelateMatch("212101212", "1********"))
+	expect(t, RelateMatch("FF2FF1212", "FF*FF****"))
+	expect(t, !RelateMatch("FF2F01212", "T********"))
+	expect(t, RelateMatch("0F1FF0102", "t*t******"))
+	expect(t, !RelateMatch("212101212", "T*T"))
+	expect(t, !RelateMatch("2121", "*********"))
+}
+
+func TestRelate(t *testing.T) {
+	a, b := RO(0, 0, 10, 10), RO(5, 5, 15, 15)
+	expect(t, a.Relate(b) == "212101212")
+	expect(t, b.Relate(a) == "212101212")
+	expect(t, a.Overlaps(b) && !a.Touches(b) && !a.Covers(b) && !a.Disjoint(b))
+	expect(t, RO(0, 0, 10, 10).Relate(RO(20, 20, 30, 30)) == "FF2FF1212")
+	expect(t, RO(0, 0, 10, 10).Disjoint(RO(20, 20, 30, 30)))
+
+	// the same polygon as a rect, a polygon, and a multipolygon
+	square := []geometry.Point{P(0, 0), P(10, 0), P(10, 10), P(0, 10), P(0, 0)}
+	mp := expectJSON(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[10,0],[10,10],[0,10],[0,0]]]]}`, nil)
+	expect(t, a.Equals(PPO(square, nil)) && PPO(square, nil).Equals(mp))
+	expect(t, a.Covers(PPO(square, nil)) && a.CoveredBy(mp))
+
+	// a multipolygon is the union of its polygons
+	halves := expectJSON(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[5,0],[5,10],[0,10],[0,0]]],
+		[[[5,0],[10,0],[10,10],[5,10],[5,0]]]]}`, nil)
+	expect(t, halves.Within(a) && halves.CoveredBy(a) && a.Covers(halves))
+	expect(t, !halves.Overlaps(a))
+	expect(t, halves.Equals(a) && a.Equals(halves))
+	expect(t, halves.Relate(a) == "2FFF1FFF2")
+
+	// touching
+	expect(t, a.Touches(RO(10, 0, 20, 10)))
+	expect(t, a.Touches(RO(10, 10, 20, 20)))
+	expect(t, a.Touches(PO(0, 5)))
+	expect(t, a.Covers(PO(0, 5)) && PO(0, 5).CoveredBy(a))
+	expect(t, !PO(0, 5).Touches(PO(0, 5)))
+	line := LO([]geometry.Point{P(10, 0), P(10, 20)})
+	expect(t, line.Touches(a) && !line.Crosses(a))
+
+	// crossing
+	line = LO([]geometry.Point{P(-5, 5), P(15, 5)})
+	expect(t, line.Crosses(a) && a.Crosses(line) && !line.Touches(a))
+	expect(t, line.Crosses(LO([]geometry.Point{P(5, 0), P(5, 10)})))
+	expect(t, !line.Crosses(LO([]geometry.Point{P(0, 5), P(10, 5)})))
+
+	// overlapping lines
+	expect(t, line.Overlaps(LO([]geometry.Point{P(10, 5), P(20, 5)})))
+	expect(t, line.Equals(LO([]geometry.Point{P(15, 5), P(5, 5), P(-5, 5)})))
+	mpo := MPO([]geometry.Point{P(1, 1), P(20, 20)})
+	expect(t, mpo.Overlaps(MPO([]geometry.Point{P(1, 1), P(30, 30)})))
+	expect(t, mpo.Crosses(a) && !mpo.Within(a))
+
+	// circles are related by their polygons
+	circle := NewCircle(P(-112, 33), 1000, 64)
+	expect(t, circle.Covers(PO(-112, 33)))
+	expect(t, circle.Disjoint(PO(-111, 33)))
+	expect(t, circle.Relate(circle) == "2FFF1FFF2")
+
+	// features use their geometry
+	f := expectJSON(t, `{"type":"Feature","geometry":
+		{"type":"Point","coordinates":[5,5]},"properties":{}}`, nil)
+	expect(t, f.Relate(a) == "0FFFFF212" && f.CoveredBy(a))
+}
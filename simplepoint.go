@@ -46,6 +46,26 @@ func (g *SimplePoint) AppendJSON(dst []byte) []byte {
 	return dst
 }
 
+// AppendWKT appends the Well-Known Text representation to dst
+func (g *SimplePoint) AppendWKT(dst []byte) []byte {
+	return appendWKTTagged(dst, "POINT", g)
+}
+
+// WKT returns the Well-Known Text representation
+func (g *SimplePoint) WKT() string {
+	return string(g.AppendWKT(nil))
+}
+
+// AppendWKB appends the Well-Known Binary representation to dst.
+func (g *SimplePoint) AppendWKB(dst []byte) []byte {
+	return appendWKB(dst, g)
+}
+
+// AppendEWKB appends the Extended Well-Known Binary representation to dst.
+func (g *SimplePoint) AppendEWKB(dst []byte, srid int) []byte {
+	return appendEWKB(dst, g, srid)
+}
+
 func (g *SimplePoint) JSON() string {
 	return string(g.AppendJSON(nil))
 }
@@ -54,6 +74,21 @@ func (g *SimplePoint) MarshalJSON() ([]byte, error) {
 	return g.AppendJSON(nil), nil
 }
 
+// MarshalBinary returns the native binary representation.
+func (g *SimplePoint) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, g), nil
+}
+
+// UnmarshalBinary loads data that was created by MarshalBinary.
+func (g *SimplePoint) UnmarshalBinary(data []byte) error {
+	o, err := unmarshalBinary(data, binSimplePoint)
+	if err != nil {
+		return err
+	}
+	*g = *o.(*SimplePoint)
+	return nil
+}
+
 func (g *SimplePoint) String() string {
 	return string(g.AppendJSON(nil))
 }
@@ -109,24 +144,105 @@ func (g *SimplePoint) NumPoints() int {
 	return 1
 }
 
+// Centroid returns the point.
+func (g *SimplePoint) Centroid() geometry.Point {
+	return centroid(g)
+}
+
+// PointOnSurface returns the point.
+func (g *SimplePoint) PointOnSurface() geometry.Point {
+	return pointOnSurface(g)
+}
+
+// PoleOfInaccessibility returns the point.
+func (g *SimplePoint) PoleOfInaccessibility(precision float64) geometry.Point {
+	return poleOfInaccessibility(g, precision)
+}
+
+// Relate returns the DE-9IM intersection matrix with obj.
+func (g *SimplePoint) Relate(obj Object) string {
+	matrix, _, _ := relate(g, obj)
+	return matrix
+}
+
+// Equals returns true if the object and obj are topologically equal.
+func (g *SimplePoint) Equals(obj Object) bool {
+	return equals(g, obj)
+}
+
+// Disjoint returns true if the object and obj have no points in common.
+func (g *SimplePoint) Disjoint(obj Object) bool {
+	return disjoint(g, obj)
+}
+
+// Touches returns true if the object and obj touch only at their edges.
+func (g *SimplePoint) Touches(obj Object) bool {
+	return touches(g, obj)
+}
+
+// Crosses returns true if the object crosses obj.
+func (g *SimplePoint) Crosses(obj Object) bool {
+	return crosses(g, obj)
+}
+
+// Overlaps returns true if the object overlaps obj.
+func (g *SimplePoint) Overlaps(obj Object) bool {
+	return overlaps(g, obj)
+}
+
+// Covers returns true if no point of obj is outside of the object.
+func (g *SimplePoint) Covers(obj Object) bool {
+	return covers(g, obj)
+}
+
+// CoveredBy returns true if no point of the object is outside of obj.
+func (g *SimplePoint) CoveredBy(obj Object) bool {
+	return coveredBy(g, obj)
+}
+
+// Buffer returns the area within a distance in meters of the point.
+func (g *SimplePoint) Buffer(meters float64, opts *geometry.BufferOptions) Object {
+	return buffer(g, meters, opts)
+}
+
+// ConvexHull returns the convex hull of the point.
+func (g *SimplePoint) ConvexHull() Object {
+	return convexHull(g)
+}
+
+// ConcaveHull returns a concave hull of the point.
+func (g *SimplePoint) ConcaveHull(ratio float64) Object {
+	return concaveHull(g, ratio)
+}
+
+// NearestPoint returns the nearest location on the point to a point.
+func (g *SimplePoint) NearestPoint(point geometry.Point) Nearest {
+	return nearestPoint(g, point)
+}
+
+// ClosestPair returns the nearest locations between the point and obj.
+func (g *SimplePoint) ClosestPair(obj Object) (a, b Nearest) {
+	return closestPair(g, obj)
+}
+
 func (g *SimplePoint) Distance(obj Object) float64 {
 	return obj.Spatial().DistancePoint(g.Point)
 }
 
 func (g *SimplePoint) DistancePoint(point geometry.Point) float64 {
-	return geoDistancePoints(g.Center(), point)
+	return geoDistancePoints(g.Point, point)
 }
 
 func (g *SimplePoint) DistanceRect(rect geometry.Rect) float64 {
-	return geoDistancePoints(g.Center(), rect.Center())
+	return distPointPoly(g.Point, rectPoly(rect))
 }
 
 func (g *SimplePoint) DistanceLine(line *geometry.Line) float64 {
-	return geoDistancePoints(g.Center(), line.Rect().Center())
+	return distPointLine(g.Point, line)
 }
 
 func (g *SimplePoint) DistancePoly(poly *geometry.Poly) float64 {
-	return geoDistancePoints(g.Center(), poly.Rect().Center())
+	return distPointPoly(g.Point, poly)
 }
 
 func (g *SimplePoint) Members() string {
@@ -0,0 +1,47 @@
+package geojson
+
+import "github.com/tidwall/geojson/geometry"
+
+// Simplify returns a simplified line, where the tolerance is in coordinate
+// units. A nil opts uses the geometry.DefaultSimplifyOptions.
+func (g *LineString) Simplify(tolerance float64, opts *geometry.SimplifyOptions,
+) Object {
+	return NewLineString(g.base.Simplify(tolerance, opts))
+}
+
+// Simplify returns a simplified polygon, where the tolerance is in coordinate
+// units. The result is an empty MultiPolygon when the polygon collapses. A
+// nil opts uses the geometry.DefaultSimplifyOptions.
+func (g *Polygon) Simplify(tolerance float64, opts *geometry.SimplifyOptions,
+) Object {
+	poly := g.base.Simplify(tolerance, opts)
+	if poly == nil {
+		return NewMultiPolygon(nil)
+	}
+	return NewPolygon(poly)
+}
+
+// Simplify returns simplified lines, where the tolerance is in coordinate
+// units. A nil opts uses the geometry.DefaultSimplifyOptions.
+func (g *MultiLineString) Simplify(tolerance float64,
+	opts *geometry.SimplifyOptions,
+) Object {
+	var lines []*geometry.Line
+	for _, part := range appendObjectParts(nil, g, 0, true) {
+		if part.line != nil {
+			lines = append(lines, part.line.Simplify(tolerance, opts))
+		}
+	}
+	return NewMultiLineString(lines)
+}
+
+// Simplify returns simplified polygons, where the tolerance is in coordinate
+// units. Polygons that collapse are removed. When preserving topology the
+// polygons are kept from crossing each other. A nil opts uses the
+// geometry.DefaultSimplifyOptions.
+func (g *MultiPolygon) Simplify(tolerance float64,
+	opts *geometry.SimplifyOptions,
+) Object {
+	return NewMultiPolygon(
+		geometry.SimplifyPolys(objectPolys(g), tolerance, opts))
+}
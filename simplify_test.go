@@ -0,0 +1,37 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func TestSimplify(t *testing.T) {
+	line := LO([]geometry.Point{P(0, 0), P(5, 0.1), P(10, 0), P(10, 10)})
+	expect(t, line.Simplify(1, nil).JSON() ==
+		`{"type":"LineString","coordinates":[[0,0],[10,0],[10,10]]}`)
+	vw := &geometry.SimplifyOptions{Algorithm: geometry.VisvalingamWhyatt}
+	expect(t, line.Simplify(1, vw).JSON() ==
+		`{"type":"LineString","coordinates":[[0,0],[10,0],[10,10]]}`)
+
+	poly := expectJSON(t, `{"type":"Polygon","coordinates":[
+		[[0,0],[5,0.1],[10,0],[10,10],[0,10],[0,0]]]}`, nil).(*Polygon)
+	expect(t, poly.Simplify(1, nil).JSON() ==
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`)
+	expect(t, poly.Simplify(100, nil).JSON() ==
+		`{"type":"MultiPolygon","coordinates":[]}`)
+	topo := &geometry.SimplifyOptions{PreserveTopology: true}
+	expect(t, poly.Simplify(100, topo).(*Polygon).Area() > 99)
+
+	mls := expectJSON(t, `{"type":"MultiLineString","coordinates":[
+		[[0,0],[5,0.1],[10,0]],[[0,5],[5,5.1],[10,5]]]}`, nil).(*MultiLineString)
+	expect(t, mls.Simplify(1, nil).JSON() ==
+		`{"type":"MultiLineString","coordinates":[[[0,0],[10,0]],[[0,5],[10,5]]]}`)
+
+	mp := expectJSON(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[5,0.1],[10,0],[10,10],[0,10],[0,0]]],
+		[[[20,0],[21,0],[21,1],[20,0]]]]}`, nil).(*MultiPolygon)
+	simple := mp.Simplify(2, nil).(*MultiPolygon)
+	expect(t, len(simple.Children()) == 1 && simple.Area() == 100)
+	expect(t, len(mp.Simplify(2, topo).(*MultiPolygon).Children()) == 2)
+}
@@ -0,0 +1,497 @@
+package geojson
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+// WKB geometry types
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+)
+
+// PostGIS EWKB type flags
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// wkbReader is a cursor over a well-known binary document.
+type wkbReader struct {
+	data  string
+	i     int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readByte() (byte, bool) {
+	if r.i+1 > len(r.data) {
+		return 0, false
+	}
+	b := r.data[r.i]
+	r.i++
+	return b, true
+}
+
+func (r *wkbReader) readUint32() (uint32, bool) {
+	if r.i+4 > len(r.data) {
+		return 0, false
+	}
+	var b [4]byte
+	copy(b[:], r.data[r.i:r.i+4])
+	r.i += 4
+	return r.order.Uint32(b[:]), true
+}
+
+func (r *wkbReader) readFloat() (float64, bool) {
+	if r.i+8 > len(r.data) {
+		return 0, false
+	}
+	var b [8]byte
+	copy(b[:], r.data[r.i:r.i+8])
+	r.i += 8
+	return math.Float64frombits(r.order.Uint64(b[:])), true
+}
+
+// readCount reads a uint32 element count and checks that there's at least
+// enough data remaining for each element to be of minSize bytes. This guards
+// against huge allocations from bad input.
+func (r *wkbReader) readCount(minSize int) (int, bool) {
+	n, ok := r.readUint32()
+	if !ok || uint64(n)*uint64(minSize) > uint64(len(r.data)-r.i) {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// readHeader reads the byte order, geometry type, dimensions and the optional
+// EWKB SRID. The m is true when the one extra coordinate value is M rather
+// than Z.
+func (r *wkbReader) readHeader() (typ uint32, dims int, m bool, err error) {
+	order, ok := r.readByte()
+	if !ok {
+		return 0, 0, false, errDataInvalid
+	}
+	switch order {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, false, errDataInvalid
+	}
+	typ, ok = r.readUint32()
+	if !ok {
+		return 0, 0, false, errDataInvalid
+	}
+	// EWKB flags
+	if typ&ewkbZ != 0 {
+		dims++
+	}
+	if typ&ewkbM != 0 {
+		dims++
+		m = typ&ewkbZ == 0
+	}
+	if typ&ewkbSRID != 0 {
+		// The SRID is read but not retained.
+		if _, ok := r.readUint32(); !ok {
+			return 0, 0, false, errDataInvalid
+		}
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	// ISO WKB types
+	switch typ / 1000 {
+	case 0:
+	case 1: // Z
+		dims, m = 1, false
+	case 2: // M
+		dims, m = 1, true
+	case 3: // ZM
+		dims, m = 2, false
+	default:
+		return 0, 0, false, errDataInvalid
+	}
+	typ %= 1000
+	return typ, dims, m, nil
+}
+
+func (r *wkbReader) readCoords(n, dims int, m bool, ex **extra) (
+	[]geometry.Point, error,
+) {
+	points := make([]geometry.Point, n)
+	if dims > 0 && *ex == nil {
+		*ex = &extra{dims: byte(dims), m: m}
+	}
+	for i := 0; i < n; i++ {
+		x, ok1 := r.readFloat()
+		y, ok2 := r.readFloat()
+		if !ok1 || !ok2 {
+			return nil, errDataInvalid
+		}
+		points[i] = geometry.Point{X: x, Y: y}
+		for j := 0; j < dims; j++ {
+			v, ok := r.readFloat()
+			if !ok {
+				return nil, errDataInvalid
+			}
+			(*ex).values = append((*ex).values, v)
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readGeometry(opts *ParseOptions) (Object, error) {
+	typ, dims, m, err := r.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	pointSize := 16 + dims*8
+	switch typ {
+	case wkbPoint:
+		var ex *extra
+		points, err := r.readCoords(1, dims, m, &ex)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(points[0].X) && math.IsNaN(points[0].Y) {
+			// empty point
+			ex = nil
+		}
+		return makePointObject(points[0], ex, opts), nil
+	case wkbLineString:
+		n, ok := r.readCount(pointSize)
+		if !ok {
+			return nil, errDataInvalid
+		}
+		if n == 1 {
+			return nil, errCoordinatesInvalid
+		}
+		var ex *extra
+		points, err := r.readCoords(n, dims, m, &ex)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			ex = nil
+		}
+		return makeLineString(points, ex, opts), nil
+	case wkbPolygon:
+		n, ok := r.readCount(4)
+		if !ok {
+			return nil, errDataInvalid
+		}
+		var ex *extra
+		rings := make([][]geometry.Point, n)
+		for i := 0; i < n; i++ {
+			np, ok := r.readCount(pointSize)
+			if !ok {
+				return nil, errDataInvalid
+			}
+			rings[i], err = r.readCoords(np, dims, m, &ex)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if n == 0 {
+			ex = nil
+		}
+		return makePolygonObject(rings, ex, opts)
+	case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon,
+		wkbGeometryCollection:
+		n, ok := r.readCount(5)
+		if !ok {
+			return nil, errDataInvalid
+		}
+		copts := opts
+		if typ == wkbMultiPoint || typ == wkbMultiPolygon {
+			// multipoint and multipolygon children are always points and
+			// polygons
+			nopts := *opts
+			nopts.AllowSimplePoints = false
+			nopts.AllowRects = false
+			copts = &nopts
+		}
+		children := make([]Object, n)
+		for i := 0; i < n; i++ {
+			children[i], err = r.readMember(typ, copts)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var c *collection
+		var o Object
+		switch typ {
+		case wkbMultiPoint:
+			g := new(MultiPoint)
+			c, o = &g.collection, g
+		case wkbMultiLineString:
+			g := new(MultiLineString)
+			c, o = &g.collection, g
+		case wkbMultiPolygon:
+			g := new(MultiPolygon)
+			c, o = &g.collection, g
+		default:
+			g := new(GeometryCollection)
+			c, o = &g.collection, g
+		}
+		c.children = children
+		c.parseInitRectIndex(opts)
+		return o, nil
+	}
+	return nil, errDataInvalid
+}
+
+// readMember reads a child geometry of a multi geometry and checks that it's
+// the expected type.
+func (r *wkbReader) readMember(parent uint32, opts *ParseOptions) (
+	Object, error,
+) {
+	child, err := r.readGeometry(opts)
+	if err != nil {
+		return nil, err
+	}
+	var ok bool
+	switch parent {
+	case wkbMultiPoint:
+		_, ok = child.(*Point)
+	case wkbMultiLineString:
+		_, ok = child.(*LineString)
+	case wkbMultiPolygon:
+		_, ok = child.(*Polygon)
+	default:
+		ok = true
+	}
+	if !ok {
+		return nil, errDataInvalid
+	}
+	return child, nil
+}
+
+func parseWKB(data string, opts *ParseOptions) (Object, error) {
+	r := &wkbReader{data: data}
+	o, err := r.readGeometry(opts)
+	if err != nil {
+		return nil, err
+	}
+	if r.i != len(r.data) {
+		return nil, errDataInvalid
+	}
+	if opts.RequireValid {
+		if !o.Valid() {
+			return nil, errCoordinatesInvalid
+		}
+	}
+	return o, nil
+}
+
+// isHexWKB returns true if the data looks like hex encoded WKB, which is how
+// PostGIS returns geometry columns by default.
+func isHexWKB(data string) bool {
+	if len(data) < 10 || len(data)%2 != 0 {
+		return false
+	}
+	if data[0] != '0' || (data[1] != '0' && data[1] != '1') {
+		return false
+	}
+	for i := 2; i < len(data); i++ {
+		c := data[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') &&
+			(c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+func parseHexWKB(data string, opts *ParseOptions) (Object, error) {
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, errDataInvalid
+	}
+	return parseWKB(string(b), opts)
+}
+
+// wkbWriter holds the options for writing well-known binary.
+type wkbWriter struct {
+	ewkb bool   // write PostGIS EWKB type flags instead of ISO WKB types
+	srid uint32 // optional EWKB SRID, zero to omit
+	m    bool   // the one extra coordinate value is M rather than Z
+}
+
+func appendWKBUint32(dst []byte, n uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], n)
+	return append(dst, b[:]...)
+}
+
+func appendWKBFloat(dst []byte, f float64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	return append(dst, b[:]...)
+}
+
+func (w wkbWriter) appendHeader(dst []byte, typ uint32, dims int) []byte {
+	dst = append(dst, 1) // little endian
+	if w.ewkb {
+		switch {
+		case dims == 1 && w.m:
+			typ |= ewkbM
+		case dims == 1:
+			typ |= ewkbZ
+		case dims == 2:
+			typ |= ewkbZ | ewkbM
+		}
+		if w.srid != 0 {
+			typ |= ewkbSRID
+		}
+		dst = appendWKBUint32(dst, typ)
+		if w.srid != 0 {
+			dst = appendWKBUint32(dst, w.srid)
+		}
+		return dst
+	}
+	switch {
+	case dims == 1 && w.m:
+		typ += 2000
+	case dims == 1:
+		typ += 1000
+	case dims == 2:
+		typ += 3000
+	}
+	return appendWKBUint32(dst, typ)
+}
+
+// appendPoint appends the coordinate values. The dims is the number of extra
+// values to write, which are padded with zeros when missing.
+func (w wkbWriter) appendPoint(
+	dst []byte, point geometry.Point, ex *extra, idx, dims int,
+) []byte {
+	dst = appendWKBFloat(dst, point.X)
+	dst = appendWKBFloat(dst, point.Y)
+	for i := 0; i < dims; i++ {
+		var v float64
+		if ex != nil && i < int(ex.dims) && idx*int(ex.dims)+i < len(ex.values) {
+			v = ex.values[idx*int(ex.dims)+i]
+		}
+		dst = appendWKBFloat(dst, v)
+	}
+	return dst
+}
+
+func (w wkbWriter) appendSeries(
+	dst []byte, series geometry.Series, ex *extra, pidx, dims int,
+) (ndst []byte, npidx int) {
+	nPoints := series.NumPoints()
+	dst = appendWKBUint32(dst, uint32(nPoints))
+	for i := 0; i < nPoints; i++ {
+		dst = w.appendPoint(dst, series.PointAt(i), ex, pidx, dims)
+		pidx++
+	}
+	return dst, pidx
+}
+
+func (w wkbWriter) appendPoly(
+	dst []byte, poly *geometry.Poly, ex *extra, dims int,
+) []byte {
+	dst = w.appendHeader(dst, wkbPolygon, dims)
+	if poly.Empty() {
+		return appendWKBUint32(dst, 0)
+	}
+	dst = appendWKBUint32(dst, uint32(1+len(poly.Holes)))
+	var pidx int
+	dst, pidx = w.appendSeries(dst, poly.Exterior, ex, pidx, dims)
+	for _, hole := range poly.Holes {
+		dst, pidx = w.appendSeries(dst, hole, ex, pidx, dims)
+	}
+	return dst
+}
+
+func (w wkbWriter) appendMulti(
+	dst []byte, typ uint32, children []Object, dims int,
+) []byte {
+	dst = w.appendHeader(dst, typ, dims)
+	dst = appendWKBUint32(dst, uint32(len(children)))
+	// only the outer geometry carries the SRID
+	w.srid = 0
+	for _, child := range children {
+		dst = w.append(dst, child, dims)
+	}
+	return dst
+}
+
+// append appends the object. The dims is the number of extra coordinate
+// values, or -1 to use the object's own dimensions.
+func (w wkbWriter) append(dst []byte, obj Object, dims int) []byte {
+	if dims == -1 {
+		dims, w.m = wktDimsOf(obj)
+	}
+	switch obj := obj.(type) {
+	case *Point:
+		dst = w.appendHeader(dst, wkbPoint, dims)
+		return w.appendPoint(dst, obj.base, obj.extra, 0, dims)
+	case *SimplePoint:
+		dst = w.appendHeader(dst, wkbPoint, dims)
+		return w.appendPoint(dst, obj.Point, nil, 0, dims)
+	case *LineString:
+		dst = w.appendHeader(dst, wkbLineString, dims)
+		dst, _ = w.appendSeries(dst, &obj.base, obj.extra, 0, dims)
+		return dst
+	case *Polygon:
+		return w.appendPoly(dst, &obj.base, obj.extra, dims)
+	case *Rect:
+		return w.appendPoly(dst, &geometry.Poly{Exterior: obj.base}, nil, dims)
+	case *Circle:
+		return w.append(dst, obj.getObject(), dims)
+	case *Feature:
+		return w.append(dst, obj.base, -1)
+	case *MultiPoint:
+		dims, w.m = wkbMultiDims(obj.children)
+		return w.appendMulti(dst, wkbMultiPoint, obj.children, dims)
+	case *MultiLineString:
+		dims, w.m = wkbMultiDims(obj.children)
+		return w.appendMulti(dst, wkbMultiLineString, obj.children, dims)
+	case *MultiPolygon:
+		dims, w.m = wkbMultiDims(obj.children)
+		return w.appendMulti(dst, wkbMultiPolygon, obj.children, dims)
+	case Collection:
+		// geometrycollection and featurecollection members keep their own
+		// dimensions
+		dst = w.appendHeader(dst, wkbGeometryCollection, 0)
+		children := obj.Children()
+		dst = appendWKBUint32(dst, uint32(len(children)))
+		w.srid = 0
+		for _, child := range children {
+			dst = w.append(dst, child, -1)
+		}
+		return dst
+	}
+	return dst
+}
+
+// wkbMultiDims returns the dimensions of the first member that has extra
+// coordinate values.
+func wkbMultiDims(children []Object) (dims int, m bool) {
+	for _, child := range children {
+		if dims, m := wktDimsOf(child); dims > 0 {
+			return dims, m
+		}
+	}
+	return 0, false
+}
+
+func appendWKB(dst []byte, obj Object) []byte {
+	return wkbWriter{}.append(dst, obj, -1)
+}
+
+func appendEWKB(dst []byte, obj Object, srid int) []byte {
+	return wkbWriter{ewkb: true, srid: uint32(srid)}.append(dst, obj, -1)
+}
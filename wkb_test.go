@@ -0,0 +1,242 @@
+package geojson
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// wkbBuf is a small WKB writer used to build test input.
+type wkbBuf struct {
+	order binary.ByteOrder
+	data  []byte
+}
+
+func newWKBBuf(bigEndian bool) *wkbBuf {
+	if bigEndian {
+		return &wkbBuf{order: binary.BigEndian}
+	}
+	return &wkbBuf{order: binary.LittleEndian}
+}
+
+func (b *wkbBuf) header(typ uint32) *wkbBuf {
+	if b.order == binary.BigEndian {
+		b.data = append(b.data, 0)
+	} else {
+		b.data = append(b.data, 1)
+	}
+	return b.uint32(typ)
+}
+
+func (b *wkbBuf) uint32(n uint32) *wkbBuf {
+	var buf [4]byte
+	b.order.PutUint32(buf[:], n)
+	b.data = append(b.data, buf[:]...)
+	return b
+}
+
+func (b *wkbBuf) floats(nums ...float64) *wkbBuf {
+	for _, f := range nums {
+		var buf [8]byte
+		b.order.PutUint64(buf[:], math.Float64bits(f))
+		b.data = append(b.data, buf[:]...)
+	}
+	return b
+}
+
+func expectWKB(t *testing.T, wkb []byte, expect interface{}) Object {
+	t.Helper()
+	return expectWKTOpts(t, string(wkb), expect, nil)
+}
+
+func TestWKBParsePoint(t *testing.T) {
+	for _, big := range []bool{false, true} {
+		expectWKB(t, newWKBBuf(big).header(1).floats(1, 2).data,
+			`{"type":"Point","coordinates":[1,2]}`)
+		expectWKB(t, newWKBBuf(big).header(1001).floats(1, 2, 3).data,
+			`{"type":"Point","coordinates":[1,2,3]}`)
+		expectWKB(t, newWKBBuf(big).header(3001).floats(1, 2, 3, 4).data,
+			`{"type":"Point","coordinates":[1,2,3,4]}`)
+		expectWKB(t, newWKBBuf(big).header(ewkbZ|ewkbM|1).
+			floats(1, 2, 3, 4).data,
+			`{"type":"Point","coordinates":[1,2,3,4]}`)
+		expectWKB(t, newWKBBuf(big).header(ewkbSRID|ewkbZ|1).uint32(4326).
+			floats(1, 2, 3).data,
+			`{"type":"Point","coordinates":[1,2,3]}`)
+	}
+	// M values are kept apart from Z values
+	for _, typ := range []uint32{2001, ewkbM | 1, ewkbSRID | ewkbM | 1} {
+		buf := newWKBBuf(false).header(typ)
+		if typ&ewkbSRID != 0 {
+			buf.uint32(4326)
+		}
+		g := expectWKB(t, buf.floats(1, 2, 3).data,
+			`{"type":"Point","coordinates":[1,2,3]}`)
+		expect(t, g.(*Point).extra.m && g.(*Point).Z() == 0)
+	}
+	for _, typ := range []uint32{1001, ewkbZ | 1} {
+		g := expectWKB(t, newWKBBuf(false).header(typ).floats(1, 2, 3).data, nil)
+		expect(t, !g.(*Point).extra.m && g.(*Point).Z() == 3)
+	}
+	for _, typ := range []uint32{3001, ewkbZ | ewkbM | 1} {
+		g := expectWKB(t, newWKBBuf(false).header(typ).floats(1, 2, 3, 4).data,
+			nil)
+		expect(t, !g.(*Point).extra.m && g.(*Point).Z() == 3)
+	}
+	g := expectWKB(t, newWKBBuf(false).header(2004).uint32(1).
+		header(2001).floats(1, 2, 3).data, nil)
+	expect(t, g.(*MultiPoint).children[0].(*Point).extra.m)
+	// empty point
+	expectWKB(t, newWKBBuf(false).header(1).floats(math.NaN(), math.NaN()).data,
+		`{"type":"Point","coordinates":[null,null]}`)
+	// hex encoded
+	expectWKT(t, `0101000000000000000000F03F0000000000000040`,
+		`{"type":"Point","coordinates":[1,2]}`)
+	expectWKT(t, `0101000020E6100000000000000000F03F0000000000000040`,
+		`{"type":"Point","coordinates":[1,2]}`)
+	expectWKT(t, `00000000013FF00000000000004000000000000000`,
+		`{"type":"Point","coordinates":[1,2]}`)
+	// bad input
+	expectWKB(t, newWKBBuf(false).header(1).floats(1).data, errDataInvalid)
+	expectWKB(t, newWKBBuf(false).header(8).floats(1, 2).data, errDataInvalid)
+	expectWKB(t, newWKBBuf(false).header(1).floats(1, 2, 3).data, errDataInvalid)
+	expectWKB(t, []byte{1, 1, 0}, errDataInvalid)
+	expectWKB(t, []byte{1}, errDataInvalid)
+	g = expectWKTOpts(t, string(newWKBBuf(false).header(1).floats(1, 2).data),
+		nil, &ParseOptions{AllowSimplePoints: true})
+	_, ok := g.(*SimplePoint)
+	expect(t, ok)
+}
+
+func TestWKBParseLineString(t *testing.T) {
+	expectWKB(t, newWKBBuf(false).header(2).uint32(2).floats(1, 2, 3, 4).data,
+		`{"type":"LineString","coordinates":[[1,2],[3,4]]}`)
+	expectWKB(t, newWKBBuf(true).header(1002).uint32(2).
+		floats(1, 2, 3, 4, 5, 6).data,
+		`{"type":"LineString","coordinates":[[1,2,3],[4,5,6]]}`)
+	expectWKB(t, newWKBBuf(false).header(2).uint32(0).data,
+		`{"type":"LineString","coordinates":[]}`)
+	expectWKB(t, newWKBBuf(false).header(2).uint32(1).floats(1, 2).data,
+		errCoordinatesInvalid)
+	expectWKB(t, newWKBBuf(false).header(2).uint32(0xFFFFFFFF).data,
+		errDataInvalid)
+}
+
+func TestWKBParsePolygon(t *testing.T) {
+	b := newWKBBuf(false).header(3).uint32(2)
+	b.uint32(5).floats(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)
+	b.uint32(5).floats(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)
+	expectWKB(t, b.data,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`)
+	b = newWKBBuf(false).header(3).uint32(1)
+	b.uint32(3).floats(0, 0, 10, 0, 0, 0)
+	expectWKB(t, b.data, errCoordinatesInvalid)
+	b = newWKBBuf(false).header(3).uint32(0)
+	expectWKB(t, b.data, `{"type":"Polygon","coordinates":[]}`)
+}
+
+func TestWKBParseMulti(t *testing.T) {
+	b := newWKBBuf(false).header(4).uint32(2)
+	b.header(1).floats(1, 2)
+	b.header(1).floats(3, 4)
+	expectWKB(t, b.data, `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`)
+
+	// mixed byte order
+	b = newWKBBuf(false).header(5).uint32(2)
+	b.header(2).uint32(2).floats(1, 2, 3, 4)
+	bb := newWKBBuf(true).header(2).uint32(2).floats(5, 6, 7, 8)
+	b.data = append(b.data, bb.data...)
+	expectWKB(t, b.data,
+		`{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`)
+
+	b = newWKBBuf(false).header(6).uint32(1)
+	b.header(3).uint32(1).uint32(4).floats(0, 0, 10, 0, 10, 10, 0, 0)
+	expectWKB(t, b.data,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[10,0],[10,10],[0,0]]]]}`)
+
+	b = newWKBBuf(false).header(7).uint32(2)
+	b.header(1).floats(1, 2)
+	b.header(2).uint32(2).floats(1, 2, 3, 4)
+	expectWKB(t, b.data,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"LineString","coordinates":[[1,2],[3,4]]}]}`)
+
+	// wrong member type
+	b = newWKBBuf(false).header(4).uint32(1)
+	b.header(2).uint32(2).floats(1, 2, 3, 4)
+	expectWKB(t, b.data, errDataInvalid)
+
+	data, _ := hex.DecodeString("0104000000020000000101000000000000000000F03F0000000000000040010100000000000000000008400000000000001040")
+	expectWKB(t, data, `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`)
+}
+
+func TestWKBAppend(t *testing.T) {
+	for _, wkt := range []string{
+		`POINT(1 2)`,
+		`POINT Z(1 2 3)`,
+		`POINT M(1 2 3)`,
+		`POINT ZM(1 2 3 4)`,
+		`POINT EMPTY`,
+		`LINESTRING(1 2,3 4.5)`,
+		`LINESTRING Z(1 2 3,3 4 5)`,
+		`LINESTRING M(1 2 3,3 4 5)`,
+		`LINESTRING EMPTY`,
+		`POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,8 2,8 8,2 8,2 2))`,
+		`POLYGON Z((0 0 1,10 0 2,10 10 3,0 0 1))`,
+		`POLYGON M((0 0 1,10 0 2,10 10 3,0 0 1))`,
+		`POLYGON EMPTY`,
+		`MULTIPOINT((1 2),(3 4))`,
+		`MULTIPOINT Z((1 2 3),(3 4 5))`,
+		`MULTIPOINT M((1 2 3),(3 4 5))`,
+		`MULTIPOINT EMPTY`,
+		`MULTILINESTRING((1 2,3 4),(5 6,7 8))`,
+		`MULTIPOLYGON ZM(((0 0 1 2,10 0 1 2,10 10 1 2,0 0 1 2)))`,
+		`GEOMETRYCOLLECTION(POINT(1 2),LINESTRING Z(1 2 3,4 5 6))`,
+		`GEOMETRYCOLLECTION(POINT M(1 2 3),POINT Z(1 2 3))`,
+		`GEOMETRYCOLLECTION EMPTY`,
+	} {
+		g := expectWKT(t, wkt, nil)
+		for _, srid := range []int{-1, 0, 4326} {
+			var wkb []byte
+			if srid == -1 {
+				wkb = g.AppendWKB(nil)
+			} else {
+				wkb = g.AppendEWKB(nil, srid)
+			}
+			g2 := expectWKB(t, wkb, nil)
+			if g2.WKT() != wkt {
+				t.Fatalf("expected '%v', got '%v'", wkt, g2.WKT())
+			}
+		}
+	}
+	expect(t, hex.EncodeToString(PO(1, 2).AppendWKB(nil)) ==
+		"0101000000000000000000f03f0000000000000040")
+	expect(t, hex.EncodeToString(PO(1, 2).AppendEWKB(nil, 4326)) ==
+		"0101000020e6100000000000000000f03f0000000000000040")
+	expect(t, hex.EncodeToString(NewPointZ(P(1, 2), 3).AppendWKB(nil)) ==
+		"01e9030000000000000000f03f00000000000000400000000000000840")
+	expect(t, hex.EncodeToString(NewPointZ(P(1, 2), 3).AppendEWKB(nil, 0)) ==
+		"0101000080000000000000f03f00000000000000400000000000000840")
+	pm := expectWKT(t, `POINT M(1 2 3)`, nil)
+	expect(t, hex.EncodeToString(pm.AppendWKB(nil)) ==
+		"01d1070000000000000000f03f00000000000000400000000000000840")
+	expect(t, hex.EncodeToString(pm.AppendEWKB(nil, 0)) ==
+		"0101000040000000000000f03f00000000000000400000000000000840")
+
+	// only the outer geometry carries the srid
+	g := expectWKT(t, `MULTIPOINT((1 2))`, nil)
+	expect(t, hex.EncodeToString(g.AppendEWKB(nil, 4326)) ==
+		"0104000020e6100000010000000101000000000000000000f03f0000000000000040")
+
+	expect(t, expectWKB(t, RO(1, 2, 3, 4).AppendWKB(nil), nil).WKT() ==
+		`POLYGON((1 2,3 2,3 4,1 4,1 2))`)
+	expect(t, expectWKB(t, NewSimplePoint(P(1, 2)).AppendWKB(nil), nil).WKT() ==
+		`POINT(1 2)`)
+	g = expectJSON(t, `{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}}`, nil)
+	expect(t, expectWKB(t, g.AppendWKB(nil), nil).WKT() == `POINT(1 2)`)
+	g = expectJSON(t, `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}}]}`, nil)
+	expect(t, expectWKB(t, g.AppendWKB(nil), nil).WKT() ==
+		`GEOMETRYCOLLECTION(POINT(1 2))`)
+	c := NewCircle(P(-112, 33), 1000, 16)
+	expect(t, expectWKB(t, c.AppendWKB(nil), nil).WKT() == c.WKT())
+}
@@ -0,0 +1,625 @@
+package geojson
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+// wktReader is a simple cursor over a well-known text document.
+type wktReader struct {
+	data string
+	i    int
+}
+
+func (r *wktReader) skipSpace() {
+	for ; r.i < len(r.data); r.i++ {
+		switch r.data[r.i] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return
+		}
+	}
+}
+
+// word reads the next keyword and returns it as upper case. An empty string
+// is returned when the next token is not a keyword.
+func (r *wktReader) word() string {
+	r.skipSpace()
+	s := r.i
+	for ; r.i < len(r.data); r.i++ {
+		c := r.data[r.i]
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			break
+		}
+	}
+	return strings.ToUpper(r.data[s:r.i])
+}
+
+// peekWord returns the next keyword without moving the cursor.
+func (r *wktReader) peekWord() string {
+	i := r.i
+	w := r.word()
+	r.i = i
+	return w
+}
+
+// char consumes the next character if it matches c.
+func (r *wktReader) char(c byte) bool {
+	r.skipSpace()
+	if r.i < len(r.data) && r.data[r.i] == c {
+		r.i++
+		return true
+	}
+	return false
+}
+
+// peek returns the next non-space character without moving the cursor.
+func (r *wktReader) peek() byte {
+	r.skipSpace()
+	if r.i < len(r.data) {
+		return r.data[r.i]
+	}
+	return 0
+}
+
+func (r *wktReader) number() (float64, bool) {
+	r.skipSpace()
+	s := r.i
+	for ; r.i < len(r.data); r.i++ {
+		c := r.data[r.i]
+		if (c < '0' || c > '9') && c != '.' && c != '-' && c != '+' &&
+			c != 'e' && c != 'E' {
+			break
+		}
+	}
+	if s == r.i {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(r.data[s:r.i], 64)
+	if err != nil {
+		r.i = s
+		return 0, false
+	}
+	return f, true
+}
+
+// wktDims returns the number of extra coordinate values for a dimension tag,
+// or -1 when the tag is missing and the dimensions should be inferred from the
+// first coordinate.
+func wktDims(tag string) int {
+	switch tag {
+	case "Z", "M":
+		return 1
+	case "ZM":
+		return 2
+	}
+	return -1
+}
+
+// wktExtra returns the extra for the coordinates of a dimension tag, before
+// any values are read, so that M values aren't taken for Z values.
+func wktExtra(tag string) *extra {
+	if tag == "M" {
+		return &extra{dims: 1, m: true}
+	}
+	return nil
+}
+
+// readCoord reads a single coordinate. The extra values, such as Z and M, are
+// appended to ex.
+func (r *wktReader) readCoord(dims *int, ex **extra) (geometry.Point, error) {
+	var count int
+	var nums [4]float64
+	for count < 4 {
+		num, ok := r.number()
+		if !ok {
+			break
+		}
+		nums[count] = num
+		count++
+	}
+	if count < 2 {
+		return geometry.Point{}, errCoordinatesInvalid
+	}
+	if *dims == -1 {
+		*dims = count - 2
+	}
+	if count-2 != *dims {
+		return geometry.Point{}, errCoordinatesInvalid
+	}
+	if *dims > 0 {
+		if *ex == nil {
+			*ex = &extra{dims: byte(*dims)}
+		}
+		(*ex).values = append((*ex).values, nums[2:count]...)
+	}
+	return geometry.Point{X: nums[0], Y: nums[1]}, nil
+}
+
+// readPoints reads a parenthesized list of coordinates.
+func (r *wktReader) readPoints(dims *int, ex **extra) ([]geometry.Point, error) {
+	if !r.char('(') {
+		return nil, errDataInvalid
+	}
+	var points []geometry.Point
+	for {
+		point, err := r.readCoord(dims, ex)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if r.char(')') {
+			return points, nil
+		}
+		if !r.char(',') {
+			return nil, errDataInvalid
+		}
+	}
+}
+
+// readRings reads a parenthesized list of coordinate lists.
+func (r *wktReader) readRings(dims *int, ex **extra) ([][]geometry.Point, error) {
+	if !r.char('(') {
+		return nil, errDataInvalid
+	}
+	var rings [][]geometry.Point
+	for {
+		points, err := r.readPoints(dims, ex)
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, points)
+		if r.char(')') {
+			return rings, nil
+		}
+		if !r.char(',') {
+			return nil, errDataInvalid
+		}
+	}
+}
+
+// readList reads a parenthesized, comma separated list of members, such as
+// the polygons of a multipolygon. The member is responsible for reading its
+// own EMPTY keyword.
+func (r *wktReader) readList(member func() error) error {
+	if !r.char('(') {
+		return errDataInvalid
+	}
+	for {
+		if err := member(); err != nil {
+			return err
+		}
+		if r.char(')') {
+			return nil
+		}
+		if !r.char(',') {
+			return errDataInvalid
+		}
+	}
+}
+
+var wktTypes = []string{
+	"POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING",
+	"MULTIPOLYGON", "GEOMETRYCOLLECTION",
+}
+
+// readHeader reads the geometry type, the optional Z/M/ZM dimension tag, and
+// the optional EMPTY keyword.
+func (r *wktReader) readHeader() (typ, tag string, empty bool, err error) {
+	typ = r.word()
+	if typ == "" {
+		return "", "", false, errDataInvalid
+	}
+	var known bool
+	for _, name := range wktTypes {
+		if typ == name {
+			known = true
+			break
+		}
+		// allow for the dimension tag to be attached to the type, eg. POINTZ
+		if strings.HasPrefix(typ, name) {
+			if wktDims(typ[len(name):]) != -1 {
+				typ, tag = name, typ[len(name):]
+				known = true
+				break
+			}
+		}
+	}
+	if !known {
+		return "", "", false, errDataInvalid
+	}
+	if tag == "" {
+		switch r.peekWord() {
+		case "Z", "M", "ZM":
+			tag = r.word()
+		}
+	}
+	if r.peekWord() == "EMPTY" {
+		r.word()
+		empty = true
+	}
+	return typ, tag, empty, nil
+}
+
+func parseWKT(data string, opts *ParseOptions) (Object, error) {
+	// strip the PostGIS EWKT "SRID=4326;" prefix
+	if len(data) > 5 && strings.EqualFold(data[:5], "SRID=") {
+		i := strings.IndexByte(data, ';')
+		if i == -1 {
+			return nil, errDataInvalid
+		}
+		data = data[i+1:]
+	}
+	r := &wktReader{data: data}
+	o, err := r.readGeometry(opts)
+	if err != nil {
+		return nil, err
+	}
+	r.skipSpace()
+	if r.i != len(r.data) {
+		return nil, errDataInvalid
+	}
+	if opts.RequireValid {
+		if !o.Valid() {
+			return nil, errCoordinatesInvalid
+		}
+	}
+	return o, nil
+}
+
+func (r *wktReader) readGeometry(opts *ParseOptions) (Object, error) {
+	typ, tag, empty, err := r.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case "POINT":
+		return r.readPoint(tag, empty, opts)
+	case "LINESTRING":
+		return r.readLineString(tag, empty, opts)
+	case "POLYGON":
+		return r.readPolygon(tag, empty, opts)
+	case "MULTIPOINT":
+		var g MultiPoint
+		if !empty {
+			err = r.readList(func() error {
+				var child Object
+				if r.peekWord() == "EMPTY" {
+					r.word()
+					child = NewPoint(geometry.Point{X: math.NaN(), Y: math.NaN()})
+				} else if r.peek() == '(' {
+					// the point is wrapped in parens, eg. MULTIPOINT((1 2))
+					points, ex, err := r.readSeries(tag)
+					if err != nil {
+						return err
+					}
+					if len(points) != 1 {
+						return errCoordinatesInvalid
+					}
+					child = &Point{base: points[0], extra: ex}
+				} else {
+					ex := wktExtra(tag)
+					dims := wktDims(tag)
+					point, err := r.readCoord(&dims, &ex)
+					if err != nil {
+						return err
+					}
+					child = &Point{base: point, extra: ex}
+				}
+				g.children = append(g.children, child)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		g.parseInitRectIndex(opts)
+		return &g, nil
+	case "MULTILINESTRING":
+		var g MultiLineString
+		if !empty {
+			err = r.readList(func() error {
+				child, err := r.readLineString(tag, r.readEmpty(), opts)
+				if err != nil {
+					return err
+				}
+				g.children = append(g.children, child)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		g.parseInitRectIndex(opts)
+		return &g, nil
+	case "MULTIPOLYGON":
+		var g MultiPolygon
+		// multipolygon children are always polygons
+		popts := *opts
+		popts.AllowRects = false
+		if !empty {
+			err = r.readList(func() error {
+				child, err := r.readPolygon(tag, r.readEmpty(), &popts)
+				if err != nil {
+					return err
+				}
+				g.children = append(g.children, child)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		g.parseInitRectIndex(opts)
+		return &g, nil
+	default: // GEOMETRYCOLLECTION
+		var g GeometryCollection
+		if !empty {
+			err = r.readList(func() error {
+				child, err := r.readGeometry(opts)
+				if err != nil {
+					return err
+				}
+				g.children = append(g.children, child)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		g.parseInitRectIndex(opts)
+		return &g, nil
+	}
+}
+
+// readEmpty consumes the EMPTY keyword of a multi geometry member.
+func (r *wktReader) readEmpty() bool {
+	if r.peekWord() == "EMPTY" {
+		r.word()
+		return true
+	}
+	return false
+}
+
+func (r *wktReader) readSeries(tag string) ([]geometry.Point, *extra, error) {
+	ex := wktExtra(tag)
+	dims := wktDims(tag)
+	points, err := r.readPoints(&dims, &ex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return points, ex, nil
+}
+
+func (r *wktReader) readPoint(tag string, empty bool, opts *ParseOptions) (
+	Object, error,
+) {
+	if empty {
+		return makePointObject(
+			geometry.Point{X: math.NaN(), Y: math.NaN()}, nil, opts), nil
+	}
+	points, ex, err := r.readSeries(tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) != 1 {
+		return nil, errCoordinatesInvalid
+	}
+	return makePointObject(points[0], ex, opts), nil
+}
+
+func (r *wktReader) readLineString(
+	tag string, empty bool, opts *ParseOptions,
+) (*LineString, error) {
+	if empty {
+		return makeLineString(nil, nil, opts), nil
+	}
+	points, ex, err := r.readSeries(tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) < 2 {
+		return nil, errCoordinatesInvalid
+	}
+	return makeLineString(points, ex, opts), nil
+}
+
+func (r *wktReader) readPolygon(tag string, empty bool, opts *ParseOptions) (
+	Object, error,
+) {
+	if empty {
+		return makePolygonObject(nil, nil, opts)
+	}
+	ex := wktExtra(tag)
+	dims := wktDims(tag)
+	rings, err := r.readRings(&dims, &ex)
+	if err != nil {
+		return nil, err
+	}
+	return makePolygonObject(rings, ex, opts)
+}
+
+func appendWKTFloat(dst []byte, f float64) []byte {
+	return strconv.AppendFloat(dst, f, 'f', -1, 64)
+}
+
+// appendWKTDims appends the dimension tag, such as " Z", for the provided
+// number of extra coordinate values. The m is true when the one extra value
+// is M rather than Z.
+func appendWKTDims(dst []byte, dims int, m bool) []byte {
+	switch dims {
+	case 1:
+		if m {
+			dst = append(dst, " M"...)
+		} else {
+			dst = append(dst, " Z"...)
+		}
+	case 2:
+		dst = append(dst, " ZM"...)
+	}
+	return dst
+}
+
+// appendWKTPoint appends the coordinate values. The dims is the number of
+// extra values to write, which are padded with zeros when missing.
+func appendWKTPoint(
+	dst []byte, point geometry.Point, ex *extra, idx, dims int,
+) []byte {
+	dst = appendWKTFloat(dst, point.X)
+	dst = append(dst, ' ')
+	dst = appendWKTFloat(dst, point.Y)
+	for i := 0; i < dims; i++ {
+		dst = append(dst, ' ')
+		if ex != nil && i < int(ex.dims) && idx*int(ex.dims)+i < len(ex.values) {
+			dst = appendWKTFloat(dst, ex.values[idx*int(ex.dims)+i])
+		} else {
+			dst = append(dst, '0')
+		}
+	}
+	return dst
+}
+
+func appendWKTSeries(
+	dst []byte, series geometry.Series, ex *extra, pidx, dims int,
+) (ndst []byte, npidx int) {
+	dst = append(dst, '(')
+	nPoints := series.NumPoints()
+	for i := 0; i < nPoints; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendWKTPoint(dst, series.PointAt(i), ex, pidx, dims)
+		pidx++
+	}
+	dst = append(dst, ')')
+	return dst, pidx
+}
+
+func appendWKTPoly(
+	dst []byte, poly *geometry.Poly, ex *extra, dims int,
+) []byte {
+	var pidx int
+	dst = append(dst, '(')
+	dst, pidx = appendWKTSeries(dst, poly.Exterior, ex, pidx, dims)
+	for _, hole := range poly.Holes {
+		dst = append(dst, ',')
+		dst, pidx = appendWKTSeries(dst, hole, ex, pidx, dims)
+	}
+	return append(dst, ')')
+}
+
+// wktDimsOf returns the number of extra coordinate values of a point,
+// linestring, or polygon, and whether the one extra value is M rather than Z.
+func wktDimsOf(obj Object) (dims int, m bool) {
+	var ex *extra
+	switch obj := obj.(type) {
+	case *Point:
+		ex = obj.extra
+	case *LineString:
+		ex = obj.extra
+	case *Polygon:
+		ex = obj.extra
+	}
+	if ex == nil || len(ex.values) == 0 {
+		return 0, false
+	}
+	return int(ex.dims), ex.m && ex.dims == 1
+}
+
+// wktBodyEmpty returns true if the point, linestring, or polygon has no
+// coordinates and should be written as EMPTY.
+func wktBodyEmpty(obj Object) bool {
+	switch obj := obj.(type) {
+	case *Point, *SimplePoint:
+		return obj.Empty()
+	case *LineString:
+		return obj.base.NumPoints() == 0
+	case *Polygon:
+		return obj.base.Empty()
+	case *Rect:
+		return false
+	}
+	return true
+}
+
+// appendWKTBody appends the coordinates of a point, linestring, or polygon,
+// without the type name.
+func appendWKTBody(dst []byte, obj Object, dims int) []byte {
+	if wktBodyEmpty(obj) {
+		return append(dst, "EMPTY"...)
+	}
+	switch obj := obj.(type) {
+	case *Point:
+		dst = append(dst, '(')
+		dst = appendWKTPoint(dst, obj.base, obj.extra, 0, dims)
+		return append(dst, ')')
+	case *SimplePoint:
+		dst = append(dst, '(')
+		dst = appendWKTPoint(dst, obj.Point, nil, 0, dims)
+		return append(dst, ')')
+	case *LineString:
+		dst, _ = appendWKTSeries(dst, &obj.base, obj.extra, 0, dims)
+		return dst
+	case *Polygon:
+		return appendWKTPoly(dst, &obj.base, obj.extra, dims)
+	default: // *Rect
+		rect := obj.(*Rect)
+		return appendWKTPoly(dst, &geometry.Poly{Exterior: rect.base}, nil, dims)
+	}
+}
+
+// appendWKTTagged appends a point, linestring, or polygon, including the type
+// name and dimension tag.
+func appendWKTTagged(dst []byte, name string, obj Object) []byte {
+	dims, m := wktDimsOf(obj)
+	dst = append(dst, name...)
+	dst = appendWKTDims(dst, dims, m)
+	if wktBodyEmpty(obj) {
+		return append(dst, " EMPTY"...)
+	}
+	return appendWKTBody(dst, obj, dims)
+}
+
+// appendWKTMulti appends a multipoint, multilinestring, or multipolygon.
+func appendWKTMulti(dst []byte, name string, children []Object) []byte {
+	dst = append(dst, name...)
+	var dims int
+	var m bool
+	for _, child := range children {
+		if dims, m = wktDimsOf(child); dims > 0 {
+			break
+		}
+	}
+	dst = appendWKTDims(dst, dims, m)
+	if len(children) == 0 {
+		return append(dst, " EMPTY"...)
+	}
+	dst = append(dst, '(')
+	for i, child := range children {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendWKTBody(dst, child, dims)
+	}
+	return append(dst, ')')
+}
+
+// appendWKTGeometryCollection appends a geometrycollection, where each child
+// writes its own tagged text.
+func appendWKTGeometryCollection(dst []byte, children []Object) []byte {
+	dst = append(dst, "GEOMETRYCOLLECTION"...)
+	if len(children) == 0 {
+		return append(dst, " EMPTY"...)
+	}
+	dst = append(dst, '(')
+	for i, child := range children {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = child.AppendWKT(dst)
+	}
+	return append(dst, ')')
+}
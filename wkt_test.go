@@ -0,0 +1,195 @@
+package geojson
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson/geometry"
+)
+
+func expectWKT(t *testing.T, wkt string, expect interface{}) Object {
+	t.Helper()
+	return expectWKTOpts(t, wkt, expect, nil)
+}
+
+func expectWKTOpts(
+	t *testing.T, wkt string, expect interface{}, opts *ParseOptions,
+) Object {
+	t.Helper()
+	var exerr error
+	var exstr string
+	switch expect := expect.(type) {
+	case string:
+		exstr = expect
+	case error:
+		exerr = expect
+	}
+	obj, err := Parse(wkt, opts)
+	if err != exerr {
+		t.Fatalf("expected '%v', got '%v'", exerr, err)
+	}
+	if exstr != "" {
+		if cleanJSON(exstr) != cleanJSON(obj.JSON()) {
+			t.Fatalf("expected '%v', got '%v'", exstr, obj.JSON())
+		}
+	}
+	return obj
+}
+
+func TestWKTParsePoint(t *testing.T) {
+	expectWKT(t, `POINT (1 2)`, `{"type":"Point","coordinates":[1,2]}`)
+	expectWKT(t, ` point(1.5 -2e1) `, `{"type":"Point","coordinates":[1.5,-20]}`)
+	expectWKT(t, `POINT Z (1 2 3)`, `{"type":"Point","coordinates":[1,2,3]}`)
+	expectWKT(t, `POINTZ(1 2 3)`, `{"type":"Point","coordinates":[1,2,3]}`)
+	expectWKT(t, `POINT M (1 2 3)`, `{"type":"Point","coordinates":[1,2,3]}`)
+	expectWKT(t, `POINT ZM (1 2 3 4)`, `{"type":"Point","coordinates":[1,2,3,4]}`)
+	expectWKT(t, `POINT (1 2 3 4)`, `{"type":"Point","coordinates":[1,2,3,4]}`)
+	expectWKT(t, `POINT EMPTY`, `{"type":"Point","coordinates":[null,null]}`)
+	expectWKT(t, `SRID=4326;POINT(1 2)`, `{"type":"Point","coordinates":[1,2]}`)
+	expectWKT(t, `POINT Z (1 2)`, errCoordinatesInvalid)
+	expectWKT(t, `POINT (1)`, errCoordinatesInvalid)
+	expectWKT(t, `POINT (1 2, 3 4)`, errCoordinatesInvalid)
+	expectWKT(t, `POINT (1 2`, errDataInvalid)
+	expectWKT(t, `POINT (1 2) x`, errDataInvalid)
+	expectWKT(t, `CIRCULARSTRING (1 2, 3 4, 5 6)`, errDataInvalid)
+	expectWKT(t, `hello`, errDataInvalid)
+	g := expectWKTOpts(t, `POINT (1 2)`, nil,
+		&ParseOptions{AllowSimplePoints: true})
+	_, ok := g.(*SimplePoint)
+	expect(t, ok)
+	g = expectWKTOpts(t, `POINT Z (1 2 3)`, nil,
+		&ParseOptions{AllowSimplePoints: true})
+	_, ok = g.(*Point)
+	expect(t, ok)
+	expectWKTOpts(t, `POINT (1 200)`, errCoordinatesInvalid,
+		&ParseOptions{RequireValid: true})
+
+	// M values are kept apart from Z values
+	g = expectWKT(t, `POINT M (1 2 3)`, nil)
+	expect(t, g.(*Point).extra.m && g.(*Point).Z() == 0)
+	expect(t, expectBinary(t, g).(*Point).extra.m)
+	g = expectWKT(t, `POINT Z (1 2 3)`, nil)
+	expect(t, !g.(*Point).extra.m && g.(*Point).Z() == 3)
+	g = expectWKT(t, `MULTIPOINT M (1 2 3, (4 5 6))`, nil)
+	for _, child := range g.(*MultiPoint).children {
+		expect(t, child.(*Point).extra.m)
+	}
+	g = expectWKT(t, `POLYGON M ((0 0 1, 1 0 2, 1 1 3, 0 0 1))`, nil)
+	expect(t, g.(*Polygon).extra.m)
+	g = expectWKT(t, `LINESTRINGM (0 0 1, 1 0 2)`, nil)
+	expect(t, g.(*LineString).extra.m)
+}
+
+func TestWKTParseLineString(t *testing.T) {
+	expectWKT(t, `LINESTRING (1 2, 3 4)`,
+		`{"type":"LineString","coordinates":[[1,2],[3,4]]}`)
+	expectWKT(t, `LINESTRING Z (1 2 3, 3 4 5)`,
+		`{"type":"LineString","coordinates":[[1,2,3],[3,4,5]]}`)
+	expectWKT(t, `LINESTRING EMPTY`,
+		`{"type":"LineString","coordinates":[]}`)
+	expectWKT(t, `LINESTRING (1 2)`, errCoordinatesInvalid)
+	expectWKT(t, `LINESTRING (1 2 3, 3 4)`, errCoordinatesInvalid)
+}
+
+func TestWKTParsePolygon(t *testing.T) {
+	expectWKT(t, `POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))`,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[8,2],[8,8],[2,8],[2,2]]]}`)
+	expectWKT(t, `POLYGON Z ((0 0 1, 10 0 2, 10 10 3, 0 0 1))`,
+		`{"type":"Polygon","coordinates":[[[0,0,1],[10,0,2],[10,10,3],[0,0,1]]]}`)
+	expectWKT(t, `POLYGON EMPTY`, `{"type":"Polygon","coordinates":[]}`)
+	expectWKT(t, `POLYGON ((0 0, 10 0, 10 10, 0 10))`, errCoordinatesInvalid)
+	expectWKT(t, `POLYGON ((0 0, 10 0, 0 0))`, errCoordinatesInvalid)
+	g := expectWKTOpts(t, `POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))`, nil,
+		&ParseOptions{AllowRects: true})
+	_, ok := g.(*Rect)
+	expect(t, ok)
+	g = expectWKTOpts(t, `POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))`, nil,
+		&ParseOptions{IndexGeometry: 1, IndexGeometryKind: geometry.QuadTree})
+	expect(t, g.(*Polygon).Base().Exterior.Index() != nil)
+}
+
+func TestWKTParseMulti(t *testing.T) {
+	expectWKT(t, `MULTIPOINT (1 2, 3 4)`,
+		`{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`)
+	expectWKT(t, `MULTIPOINT ((1 2), (3 4))`,
+		`{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`)
+	expectWKT(t, `MULTIPOINT Z ((1 2 3), (3 4 5))`,
+		`{"type":"MultiPoint","coordinates":[[1,2,3],[3,4,5]]}`)
+	expectWKT(t, `MULTIPOINT EMPTY`,
+		`{"type":"MultiPoint","coordinates":[]}`)
+	expectWKT(t, `MULTILINESTRING ((1 2, 3 4), (5 6, 7 8))`,
+		`{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`)
+	expectWKT(t, `MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0)), ((20 20, 30 20, 30 30, 20 20)))`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[10,0],[10,10],[0,10],[0,0]]],[[[20,20],[30,20],[30,30],[20,20]]]]}`)
+	g := expectWKTOpts(t,
+		`MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0)))`, nil,
+		&ParseOptions{AllowRects: true})
+	_, ok := g.(*MultiPolygon).Children()[0].(*Polygon)
+	expect(t, ok)
+	expectWKT(t, `MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0))`, errDataInvalid)
+}
+
+func TestWKTParseGeometryCollection(t *testing.T) {
+	expectWKT(t, `GEOMETRYCOLLECTION (POINT (1 2), LINESTRING Z (1 2 3, 4 5 6))`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"LineString","coordinates":[[1,2,3],[4,5,6]]}]}`)
+	expectWKT(t, `GEOMETRYCOLLECTION EMPTY`,
+		`{"type":"GeometryCollection","geometries":[]}`)
+	g := expectWKT(t, `GEOMETRYCOLLECTION (POINT (1 2), POINT (3 4))`, nil)
+	expect(t, g.Rect() == R(1, 2, 3, 4))
+	expect(t, g.Intersects(PO(3, 4)))
+}
+
+func TestWKTAppend(t *testing.T) {
+	for _, wkt := range []string{
+		`POINT(1 2)`,
+		`POINT Z(1 2 3)`,
+		`POINT M(1 2 3)`,
+		`POINT ZM(1 2 3 4)`,
+		`POINT EMPTY`,
+		`LINESTRING(1 2,3 4.5)`,
+		`LINESTRING Z(1 2 3,3 4 5)`,
+		`LINESTRING M(1 2 3,3 4 5)`,
+		`LINESTRING ZM(1 2 3 4,3 4 5 6)`,
+		`LINESTRING EMPTY`,
+		`POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,8 2,8 8,2 8,2 2))`,
+		`POLYGON Z((0 0 1,10 0 2,10 10 3,0 0 1))`,
+		`POLYGON M((0 0 1,10 0 2,10 10 3,0 0 1))`,
+		`POLYGON EMPTY`,
+		`MULTIPOINT((1 2),(3 4))`,
+		`MULTIPOINT Z((1 2 3),(3 4 5))`,
+		`MULTIPOINT M((1 2 3),(3 4 5))`,
+		`MULTILINESTRING ZM((1 2 3 4,3 4 5 6))`,
+		`MULTIPOINT EMPTY`,
+		`MULTILINESTRING((1 2,3 4),(5 6,7 8))`,
+		`MULTIPOLYGON(((0 0,10 0,10 10,0 0)),((20 20,30 20,30 30,20 20)))`,
+		`MULTIPOLYGON EMPTY`,
+		`GEOMETRYCOLLECTION(POINT(1 2),LINESTRING Z(1 2 3,4 5 6))`,
+		`GEOMETRYCOLLECTION(POINT M(1 2 3),POINT Z(1 2 3))`,
+		`GEOMETRYCOLLECTION EMPTY`,
+	} {
+		g := expectWKT(t, wkt, nil)
+		if g.WKT() != wkt {
+			t.Fatalf("expected '%v', got '%v'", wkt, g.WKT())
+		}
+	}
+	expect(t, RO(1, 2, 3, 4).WKT() == `POLYGON((1 2,3 2,3 4,1 4,1 2))`)
+	expect(t, NewSimplePoint(P(1, 2)).WKT() == `POINT(1 2)`)
+	g := expectJSON(t, `{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2,3]},"properties":{}}`, nil)
+	expect(t, g.WKT() == `POINT Z(1 2 3)`)
+	g = expectJSON(t, `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}}]}`, nil)
+	expect(t, g.WKT() == `GEOMETRYCOLLECTION(POINT(1 2))`)
+	// M values are still M after a binary round trip and a split at the
+	// antimeridian
+	g = expectWKT(t, `LINESTRING M(170 0 1,-170 0 3)`, nil)
+	expect(t, expectBinary(t, g).WKT() == `LINESTRING M(170 0 1,-170 0 3)`)
+	g = expectWKTOpts(t, `LINESTRING M(170 0 1,-170 0 3)`, nil,
+		&ParseOptions{SplitAntimeridian: true})
+	expect(t, g.WKT() == `MULTILINESTRING M((170 0 1,180 0 2),(-180 0 2,-170 0 3))`)
+	// mixed dimensions are padded with zeros
+	g = expectJSON(t, `{"type":"MultiPoint","coordinates":[[1,2],[3,4,5]]}`, nil)
+	expect(t, g.WKT() == `MULTIPOINT Z((1 2 0),(3 4 5))`)
+	// circles are written as their polygon approximation
+	c := NewCircle(P(-112, 33), 1000, 16)
+	cg, err := Parse(c.WKT(), nil)
+	expect(t, err == nil)
+	expect(t, cg.NumPoints() == c.Polygon().NumPoints())
+}